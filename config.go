@@ -0,0 +1,237 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigFile is the configuration file looked up in the working directory when -config is not given
+const defaultConfigFile = "sdk-ts-gen.yaml"
+
+// Config is the configuration file of the generator. Settings missing from the file keep their
+// defaults, and the command-line flags override the settings of the file.
+type Config struct {
+	// Doc is the path of the OpenAPI document, relative to the configuration file
+	Doc    string       `yaml:"doc"`
+	Output OutputConfig `yaml:"output"`
+
+	// ClassName is the name of the SDK class, also prefixing its options types
+	ClassName string `yaml:"className"`
+
+	// DefaultBaseURL is the base URL of the SDK when the document declares no servers
+	DefaultBaseURL string `yaml:"defaultBaseUrl"`
+
+	Headers HeadersConfig `yaml:"headers"`
+
+	// IncludeFallback are the values of include parameters declaring no enum, when none are
+	// declared with x-gocart-includes or derived from the response
+	IncludeFallback []string `yaml:"includeFallback"`
+
+	// IncludeDepth is the depth of the include values derived from the _embedded relationships of
+	// responses, 0 disabling them; nil keeps the default
+	IncludeDepth *int `yaml:"includeDepth"`
+
+	Features FeaturesConfig `yaml:"features"`
+}
+
+// OutputConfig controls where the generated files are written
+type OutputConfig struct {
+	// Dir is the output directory, relative to the configuration file
+	Dir string `yaml:"dir"`
+
+	// SDKFile is the name of the file of the SDK class
+	SDKFile string `yaml:"sdkFile"`
+}
+
+// HeadersConfig holds the names of the headers sent and read by the SDK
+type HeadersConfig struct {
+	SDKVersion string `yaml:"sdkVersion"`
+	TotalCount string `yaml:"totalCount"`
+}
+
+// FeaturesConfig toggles optional features of the generated SDK; nil keeps the default
+type FeaturesConfig struct {
+	GroupByTag                 *bool `yaml:"groupByTag"`
+	StrictAdditionalProperties *bool `yaml:"strictAdditionalProperties"`
+	Pagination                 *bool `yaml:"pagination"`
+	WithResponse               *bool `yaml:"withResponse"`
+}
+
+var (
+	// headerNamePattern matches valid HTTP header names (RFC 7230 tokens)
+	headerNamePattern = regexp.MustCompile("^[!#$%&'*+.^_`|~0-9A-Za-z-]+$")
+
+	// includeValuePattern matches include values, e.g. parent or items.product
+	includeValuePattern = regexp.MustCompile(`^[A-Za-z0-9_]+(\.[A-Za-z0-9_]+)*$`)
+)
+
+// findConfigFile returns the configuration file to load: the given path, or else the default
+// configuration file if it exists in the working directory
+func findConfigFile(path string) string {
+	if path != "" {
+		return path
+	}
+	if _, err := os.Stat(defaultConfigFile); err == nil {
+		return defaultConfigFile
+	}
+	return ""
+}
+
+// loadConfig reads and validates a configuration file. Relative paths of the file are
+// resolved against its directory.
+func loadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config, err := parseConfig(data)
+	if err != nil {
+		return nil, configErrors(path, err)
+	}
+
+	dir := filepath.Dir(path)
+	if config.Doc != "" && config.Doc != "-" && !filepath.IsAbs(config.Doc) {
+		config.Doc = filepath.Join(dir, config.Doc)
+	}
+	if config.Output.Dir != "" && !filepath.IsAbs(config.Output.Dir) {
+		config.Output.Dir = filepath.Join(dir, config.Output.Dir)
+	}
+
+	return config, nil
+}
+
+// parseConfig decodes and validates the content of a configuration file. Unknown settings are rejected.
+func parseConfig(data []byte) (*Config, error) {
+	config := &Config{}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	if err := config.validate(); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// configErrors reports the errors of a configuration file one per line, prefixed with its path
+func configErrors(path string, err error) error {
+	var messages []string
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	} else {
+		messages = strings.Split(err.Error(), "\n")
+	}
+	for i, message := range messages {
+		messages[i] = fmt.Sprintf("%s: %s", path, strings.TrimPrefix(message, "yaml: "))
+	}
+	return errors.New(strings.Join(messages, "\n"))
+}
+
+// validate checks the settings of the configuration, reporting all the invalid ones
+func (c *Config) validate() error {
+	var errs []error
+
+	if c.ClassName != "" && !identifierPattern.MatchString(c.ClassName) {
+		errs = append(errs, fmt.Errorf("className: %q is not a valid TypeScript identifier", c.ClassName))
+	}
+
+	if c.DefaultBaseURL != "" {
+		u, err := url.Parse(c.DefaultBaseURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("defaultBaseUrl: %q is not an absolute http(s) URL", c.DefaultBaseURL))
+		}
+	}
+
+	if c.Output.SDKFile != "" {
+		switch {
+		case strings.ContainsAny(c.Output.SDKFile, `/\`) || !strings.HasSuffix(c.Output.SDKFile, ".ts"):
+			errs = append(errs, fmt.Errorf("output.sdkFile: %q must be a .ts file name", c.Output.SDKFile))
+		case isReservedOutputFile(c.Output.SDKFile):
+			errs = append(errs, fmt.Errorf("output.sdkFile: %q is already used by the generated files", c.Output.SDKFile))
+		}
+	}
+
+	if c.Headers.SDKVersion != "" && !headerNamePattern.MatchString(c.Headers.SDKVersion) {
+		errs = append(errs, fmt.Errorf("headers.sdkVersion: %q is not a valid header name", c.Headers.SDKVersion))
+	}
+	if c.Headers.TotalCount != "" && !headerNamePattern.MatchString(c.Headers.TotalCount) {
+		errs = append(errs, fmt.Errorf("headers.totalCount: %q is not a valid header name", c.Headers.TotalCount))
+	}
+
+	for i, value := range c.IncludeFallback {
+		if !includeValuePattern.MatchString(value) {
+			errs = append(errs, fmt.Errorf("includeFallback[%d]: %q is not a valid include value", i, value))
+		}
+	}
+	if c.IncludeDepth != nil && *c.IncludeDepth < 0 {
+		errs = append(errs, fmt.Errorf("includeDepth: %d must not be negative", *c.IncludeDepth))
+	}
+
+	return errors.Join(errs...)
+}
+
+// isReservedOutputFile reports whether a file name is used by the types, params or runtime files
+func isReservedOutputFile(name string) bool {
+	if name == "types.ts" || name == "params.ts" {
+		return true
+	}
+	runtimeFiles, err := getRuntimeFiles()
+	if err != nil {
+		return false
+	}
+	for _, f := range runtimeFiles {
+		if f.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// apply sets the generator options from the settings of the configuration
+func (c *Config) apply(options *GeneratorOptions) {
+	if c.ClassName != "" {
+		options.ClassName = c.ClassName
+	}
+	if c.DefaultBaseURL != "" {
+		options.DefaultBaseURL = strings.TrimRight(c.DefaultBaseURL, "/")
+	}
+	if c.Headers.SDKVersion != "" {
+		options.SDKVersionHeader = c.Headers.SDKVersion
+	}
+	if c.Headers.TotalCount != "" {
+		options.TotalCountHeader = c.Headers.TotalCount
+	}
+	if c.IncludeFallback != nil {
+		options.IncludeFallback = c.IncludeFallback
+	}
+	if c.IncludeDepth != nil {
+		options.IncludeDepth = *c.IncludeDepth
+	}
+
+	for _, feature := range []struct {
+		value  *bool
+		target *bool
+	}{
+		{c.Features.GroupByTag, &options.GroupByTag},
+		{c.Features.StrictAdditionalProperties, &options.StrictAdditionalProperties},
+		{c.Features.Pagination, &options.Pagination},
+		{c.Features.WithResponse, &options.WithResponse},
+	} {
+		if feature.value != nil {
+			*feature.target = *feature.value
+		}
+	}
+}
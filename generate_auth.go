@@ -0,0 +1,220 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SecuritySchemeDefinition represents a security scheme supported by the generated SDK
+type SecuritySchemeDefinition struct {
+	Name           string
+	Scheme         *openapi3.SecurityScheme
+	CredentialType string // Type of the credentials in the SDK auth options, e.g. TokenProvider
+	Literal        string // SecurityScheme literal consumed by the auth runtime
+}
+
+// identifierPattern matches names that can be used as TypeScript property names without quotes
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_$][A-Za-z0-9_$]*$`)
+
+// propertyKey renders a name as a TypeScript property key, quoting it when needed
+func propertyKey(name string) string {
+	if identifierPattern.MatchString(name) {
+		return name
+	}
+	return quoteLiteral(name)
+}
+
+// propertyAccessor returns the accessor of an object property, e.g. .name or ['x-name']
+func propertyAccessor(name string) string {
+	if identifierPattern.MatchString(name) {
+		return "." + name
+	}
+	return "[" + quoteLiteral(name) + "]"
+}
+
+// getSecuritySchemes returns the security schemes of the document sorted by name. Schemes the
+// SDK cannot apply (e.g. mutualTLS or HTTP digest) are skipped.
+func getSecuritySchemes(doc *openapi3.T) []SecuritySchemeDefinition {
+	if doc.Components == nil {
+		return nil
+	}
+
+	var schemes []SecuritySchemeDefinition
+	for name, schemeRef := range doc.Components.SecuritySchemes {
+		if schemeRef == nil || schemeRef.Value == nil {
+			continue
+		}
+		scheme := schemeRef.Value
+
+		definition := SecuritySchemeDefinition{Name: name, Scheme: scheme}
+		switch scheme.Type {
+		case "http":
+			switch strings.ToLower(scheme.Scheme) {
+			case "bearer":
+				definition.CredentialType = "TokenProvider"
+				definition.Literal = "{ type: 'http', scheme: 'bearer' }"
+			case "basic":
+				definition.CredentialType = "BasicCredentials"
+				definition.Literal = "{ type: 'http', scheme: 'basic' }"
+			default:
+				continue
+			}
+		case "apiKey":
+			if scheme.In != "header" && scheme.In != "query" && scheme.In != "cookie" {
+				continue
+			}
+			definition.CredentialType = "TokenProvider"
+			definition.Literal = fmt.Sprintf("{ type: 'apiKey', in: '%s', name: %s }", scheme.In, quoteLiteral(scheme.Name))
+		case "oauth2":
+			tokenURL, refreshURL := oauth2URLs(scheme.Flows)
+			fields := []string{"type: 'oauth2'"}
+			if tokenURL != "" {
+				fields = append(fields, fmt.Sprintf("tokenUrl: %s", quoteLiteral(tokenURL)))
+			}
+			if refreshURL != "" {
+				fields = append(fields, fmt.Sprintf("refreshUrl: %s", quoteLiteral(refreshURL)))
+			}
+			definition.CredentialType = "OAuth2Credentials"
+			definition.Literal = fmt.Sprintf("{ %s }", strings.Join(fields, ", "))
+		case "openIdConnect":
+			// OpenID Connect access tokens are sent as bearer tokens
+			definition.CredentialType = "TokenProvider"
+			definition.Literal = "{ type: 'http', scheme: 'bearer' }"
+		default:
+			continue
+		}
+		schemes = append(schemes, definition)
+	}
+
+	sort.Slice(schemes, func(i, j int) bool {
+		return schemes[i].Name < schemes[j].Name
+	})
+
+	return schemes
+}
+
+// oauth2URLs returns the token and refresh URLs of the flows the SDK can use to obtain tokens,
+// preferring the client credentials flow
+func oauth2URLs(flows *openapi3.OAuthFlows) (string, string) {
+	if flows == nil {
+		return "", ""
+	}
+	var tokenURL, refreshURL string
+	for _, flow := range []*openapi3.OAuthFlow{flows.ClientCredentials, flows.AuthorizationCode, flows.Password} {
+		if flow == nil {
+			continue
+		}
+		if tokenURL == "" {
+			tokenURL = flow.TokenURL
+		}
+		if refreshURL == "" {
+			refreshURL = flow.RefreshURL
+		}
+	}
+	return tokenURL, refreshURL
+}
+
+// getSecurityRequirements returns the alternative sets of security schemes an operation
+// requires: its own security, or else the document security. Scheme names of each set are
+// sorted; an empty result means the operation does not need authentication.
+func getSecurityRequirements(doc *openapi3.T, operation *openapi3.Operation) [][]string {
+	requirements := doc.Security
+	if operation.Security != nil {
+		requirements = *operation.Security
+	}
+
+	result := [][]string{}
+	for _, requirement := range requirements {
+		names := make([]string, 0, len(requirement))
+		for name := range requirement {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		result = append(result, names)
+	}
+	return result
+}
+
+// securityRequirementsLiteral renders security requirements as a TypeScript array literal,
+// e.g. [['apiKey'], ['oauth2']]
+func securityRequirementsLiteral(requirements [][]string) string {
+	sets := make([]string, 0, len(requirements))
+	for _, names := range requirements {
+		quoted := make([]string, 0, len(names))
+		for _, name := range names {
+			quoted = append(quoted, quoteLiteral(name))
+		}
+		sets = append(sets, "["+strings.Join(quoted, ", ")+"]")
+	}
+	return "[" + strings.Join(sets, ", ") + "]"
+}
+
+// securitySchemeDocLines returns the JSDoc lines describing the credentials of a security scheme
+func securitySchemeDocLines(definition SecuritySchemeDefinition) []string {
+	scheme := definition.Scheme
+
+	var lines []string
+	if scheme.Description != "" {
+		lines = append(lines, strings.Split(strings.TrimSpace(scheme.Description), "\n")...)
+	}
+	switch scheme.Type {
+	case "http":
+		if strings.EqualFold(scheme.Scheme, "basic") {
+			lines = append(lines, "HTTP basic authentication")
+		} else if scheme.BearerFormat != "" {
+			lines = append(lines, fmt.Sprintf("HTTP bearer authentication (%s)", scheme.BearerFormat))
+		} else {
+			lines = append(lines, "HTTP bearer authentication")
+		}
+	case "apiKey":
+		lines = append(lines, fmt.Sprintf("API key sent in the %s %s", scheme.Name, scheme.In))
+	case "oauth2":
+		lines = append(lines, "OAuth2 access token, or client credentials to obtain one")
+	case "openIdConnect":
+		lines = append(lines, "OpenID Connect access token")
+	}
+	return lines
+}
+
+// generateAuthTypes generates the credentials type of the SDK options and the security
+// schemes consumed by the auth runtime
+func generateAuthTypes(schemes []SecuritySchemeDefinition) string {
+	var buf bytes.Buffer
+
+	buf.WriteString("/**\n")
+	buf.WriteString(" * Credentials of the security schemes declared by the API\n")
+	buf.WriteString(" */\n")
+	buf.WriteString(fmt.Sprintf("export interface %s {\n", sdkTypeName("Auth")))
+	for i, s := range schemes {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		buf.WriteString(renderJSDoc(securitySchemeDocLines(s), "  "))
+		buf.WriteString(fmt.Sprintf("  %s?: %s;\n", propertyKey(s.Name), s.CredentialType))
+	}
+	buf.WriteString("}\n\n")
+
+	buf.WriteString("const SECURITY_SCHEMES: Record<string, SecurityScheme> = {\n")
+	for _, s := range schemes {
+		buf.WriteString(fmt.Sprintf("  %s: %s,\n", propertyKey(s.Name), s.Literal))
+	}
+	buf.WriteString("};\n\n")
+
+	return buf.String()
+}
+
+// authImports returns the names imported from the auth runtime by the generated SDK
+func authImports(schemes []SecuritySchemeDefinition) []string {
+	imports := []string{"AuthManager", "SecurityScheme"}
+	for _, s := range schemes {
+		imports = append(imports, s.CredentialType)
+	}
+	imports = removeDuplicates(imports)
+	sort.Strings(imports)
+	return imports
+}
@@ -0,0 +1,225 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// SparseFieldsDefinition describes the narrowing of the response of an operation to the sparse
+// fieldset (fields[type] parameter) of the resource it returns
+type SparseFieldsDefinition struct {
+	Key       string // Resource type of the fields parameter, e.g. product for fields[product]
+	FieldType string // Union of the field names of the resource, e.g. ProductField
+}
+
+// resourceSchemaName returns the name of the component schema of a resource type, matching
+// fields[product] or fields[products] with a Product schema
+func resourceSchemaName(doc *openapi3.T, key string) (string, bool) {
+	if doc.Components == nil {
+		return "", false
+	}
+
+	candidates := []string{toPascalCase(key)}
+	if singular := strings.TrimSuffix(key, "s"); singular != key && singular != "" {
+		candidates = append(candidates, toPascalCase(singular))
+	}
+	for _, candidate := range candidates {
+		for _, name := range sortedKeys(doc.Components.Schemas) {
+			if toPascalCase(name) == candidate && len(resourceFieldNames(doc.Components.Schemas[name], doc)) > 0 {
+				return name, true
+			}
+		}
+	}
+	return "", false
+}
+
+// resourceFieldNames returns the camelCase names of the properties of a resource schema,
+// including the ones of its allOf members and its _embedded relationships. Union schemas have
+// no fixed set of fields and return nil.
+func resourceFieldNames(schemaRef *openapi3.SchemaRef, doc *openapi3.T) []string {
+	fields := resourceFields(schemaRef, doc)
+	if fields == nil {
+		return nil
+	}
+	return sortedKeys(fields)
+}
+
+// resourceFields returns the names of the properties of a resource schema as sent to the API,
+// keyed by their camelCase names, e.g. unitPrice: unit_price. Union schemas return nil.
+func resourceFields(schemaRef *openapi3.SchemaRef, doc *openapi3.T) map[string]string {
+	if schemaRef == nil || schemaRef.Value == nil {
+		return nil
+	}
+	schema := schemaRef.Value
+	if len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 {
+		return nil
+	}
+
+	fields := make(map[string]string)
+	for _, member := range schema.AllOf {
+		memberFields := resourceFields(member, doc)
+		if memberFields == nil {
+			return nil
+		}
+		for camelName, name := range memberFields {
+			fields[camelName] = name
+		}
+	}
+
+	props, err := collectProperties([]*openapi3.Schema{schema}, nil, doc)
+	if err != nil {
+		return nil
+	}
+	for _, prop := range props {
+		fields[prop.camelName] = prop.name
+	}
+	return fields
+}
+
+// fieldTypeName returns the name of the union of the field names of a resource schema
+func fieldTypeName(schemaName string) string {
+	return toPascalCase(schemaName) + "Field"
+}
+
+// fieldNamesName returns the name of the table of the field names sent to the API for a resource
+// schema, e.g. productFieldNames
+func fieldNamesName(schemaName string) string {
+	return toCamelCase(schemaName) + "FieldNames"
+}
+
+// generateFieldNames generates the table mapping the field names of a resource schema to the
+// names of its properties, the ones the API expects in fields[type] parameters
+func generateFieldNames(schemaName string, fields map[string]string) string {
+	entries := make([]string, 0, len(fields))
+	for _, camelName := range sortedKeys(fields) {
+		entries = append(entries, fmt.Sprintf("%s: %s", propertyKey(camelName), quoteLiteral(fields[camelName])))
+	}
+	return fmt.Sprintf("export const %s: Record<%s, string> = { %s };", fieldNamesName(schemaName), fieldTypeName(schemaName), strings.Join(entries, ", "))
+}
+
+// fieldNamesImports returns the field names tables used by the fields[type] parameters of the methods
+func fieldNamesImports(methodDefinitions MethodDefinitions) []string {
+	var imports []string
+	for _, m := range methodDefinitions {
+		for _, qp := range m.QueryParams[openapi3.ParameterInQuery] {
+			if isSparseFieldsParam(qp) && !contains(imports, fieldNamesName(qp.Resource)) {
+				imports = append(imports, fieldNamesName(qp.Resource))
+			}
+		}
+	}
+	return imports
+}
+
+// isSparseFieldsParam reports whether a query parameter is a fields[type] parameter of a
+// resource whose fields are known
+func isSparseFieldsParam(qp QueryParameter) bool {
+	return qp.Resource != ""
+}
+
+// withSparseFields sets the resource schema of the fields[type] query parameters matching a
+// component schema
+func withSparseFields(doc *openapi3.T, params []QueryParameter) []QueryParameter {
+	for i, param := range params {
+		if param.In != openapi3.ParameterInQuery || !isBracketParam(param.Name) {
+			continue
+		}
+		base, key := splitBracketParam(param.Name)
+		if base != "fields" {
+			continue
+		}
+		if name, ok := resourceSchemaName(doc, key); ok {
+			params[i].Resource = name
+		}
+	}
+	return params
+}
+
+// getSparseFieldsDefinition returns how the response of an operation is narrowed by the
+// sparse fieldset of the resource it returns, or nil when the operation has no fields parameter for it
+func getSparseFieldsDefinition(doc *openapi3.T, operation *openapi3.Operation, params []QueryParameter) *SparseFieldsDefinition {
+	hasSparseFields := false
+	for _, param := range params {
+		hasSparseFields = hasSparseFields || isSparseFieldsParam(param)
+	}
+	if !hasSparseFields {
+		return nil
+	}
+	resource := getResponseResource(operation)
+	if resource == nil || resource.Schema.Ref == "" {
+		return nil
+	}
+
+	for _, param := range params {
+		if isSparseFieldsParam(param) && getRefName(resource.Schema.Ref) == param.Resource {
+			_, key := splitBracketParam(param.Name)
+			return &SparseFieldsDefinition{
+				Key:       key,
+				FieldType: fieldTypeName(param.Resource),
+			}
+		}
+	}
+	return nil
+}
+
+// typeParameter returns the type parameter of the fields F of the resource, defaulting to all of them
+func (s *SparseFieldsDefinition) typeParameter() string {
+	return fmt.Sprintf("F extends %s = %s", s.FieldType, s.FieldType)
+}
+
+// generateFieldsInterface generates the type of the fields group of a params interface, keyed by
+// resource type. The fields of known resources are arrays of their field names, the fields of
+// the resource narrowing the response being the type parameter F.
+func generateFieldsInterface(params []QueryParameter, sparseFields *SparseFieldsDefinition, doc *openapi3.T) (string, []string) {
+	var buf strings.Builder
+	var additionalTypes []string
+
+	buf.WriteString("{\n")
+	for _, param := range params {
+		if param.Description != "" {
+			buf.WriteString(fmt.Sprintf("    /**\n     * %s\n     */\n", param.Description))
+		}
+
+		var tsType string
+		if isSparseFieldsParam(param) {
+			fields := resourceFields(doc.Components.Schemas[param.Resource], doc)
+			members := make([]string, 0, len(fields))
+			for _, name := range sortedKeys(fields) {
+				members = append(members, quoteLiteral(name))
+			}
+			additionalTypes = append(additionalTypes,
+				fmt.Sprintf("export type %s = %s;", fieldTypeName(param.Resource), strings.Join(members, " | ")),
+				generateFieldNames(param.Resource, fields))
+
+			tsType = fieldTypeName(param.Resource) + "[]"
+			if sparseFields != nil && sparseFields.Key == param.Name {
+				tsType = "F[]"
+			}
+		} else if param.SDKType != "" {
+			tsType = param.SDKType
+		} else {
+			var additional []string
+			tsType, additional = resolveType(param.Schema, doc)
+			additionalTypes = append(additionalTypes, additional...)
+			if param.Schema != nil && param.Schema.Value != nil && param.Schema.Value.Nullable {
+				tsType = fmt.Sprintf("%s | null", tsType)
+			}
+		}
+
+		optional := "?:"
+		if param.Required {
+			optional = ":"
+		}
+		buf.WriteString(fmt.Sprintf("    %s%s %s;\n\n", propertyKey(toCamelCase(param.Name)), optional, indentType(tsType, "    ")))
+	}
+	buf.WriteString("  }")
+
+	return buf.String(), additionalTypes
+}
+
+// generateSparseFieldsParam generates the code sending the field names of a fields[type]
+// parameter as the names of the properties of the resource
+func generateSparseFieldsParam(qp QueryParameter, value string) string {
+	return listAppendStatement(qp, fmt.Sprintf("%s.map((v) => %s[v])", value, fieldNamesName(qp.Resource)))
+}
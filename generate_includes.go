@@ -0,0 +1,135 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// IncludesDefinition describes the narrowing of the response of an operation to the
+// relationships requested with its include parameter
+type IncludesDefinition struct {
+	OptionType string // Union of the include values, e.g. GetOrderParamsIncludeOption
+}
+
+// typeParameter returns the type parameter of the included relationships I, none by default
+func (i *IncludesDefinition) typeParameter() string {
+	return fmt.Sprintf("I extends %s = never", i.OptionType)
+}
+
+// includeOptionTypeName returns the name of the union of the include values of a params interface
+func includeOptionTypeName(interfaceName string) string {
+	return interfaceName + "IncludeOption"
+}
+
+// getIncludesDefinition returns how the response of an operation is narrowed by its include
+// parameter, or nil when it has none, or no named resource to mark the relationships of
+func getIncludesDefinition(doc *openapi3.T, operation *openapi3.Operation, params []QueryParameter, interfaceName string) *IncludesDefinition {
+	var includeParams []QueryParameter
+	for _, param := range params {
+		if param.In == openapi3.ParameterInQuery && param.Name == "include" {
+			includeParams = append(includeParams, param)
+		}
+	}
+	if len(includeParams) == 0 || len(extractEnumValues("include", includeParams, operation, doc)) == 0 {
+		return nil
+	}
+
+	resource := getResponseResource(operation)
+	if resource == nil || resource.Type == "" {
+		return nil
+	}
+	return &IncludesDefinition{OptionType: includeOptionTypeName(interfaceName)}
+}
+
+// includeValues returns the values of an include parameter declaring no enum: the ones of the
+// x-gocart-includes extension of the parameter or of its operation, or else the relationships
+// embedded in the resource the operation returns, or else the configured fallback values
+func includeValues(operation *openapi3.Operation, doc *openapi3.T) []string {
+	if operation == nil {
+		return generatorOptions.IncludeFallback
+	}
+
+	if param := operation.Parameters.GetByInAndName(openapi3.ParameterInQuery, "include"); param != nil {
+		if values, ok := includesExtension(param.Extensions); ok {
+			return values
+		}
+	}
+	if values, ok := includesExtension(operation.Extensions); ok {
+		return values
+	}
+
+	if resource := getResponseResource(operation); resource != nil {
+		if values := embeddedIncludePaths(resource.Schema, "", generatorOptions.IncludeDepth); len(values) > 0 {
+			return values
+		}
+	}
+	return generatorOptions.IncludeFallback
+}
+
+// includesExtension returns the values of the x-gocart-includes extension, if set to a list of strings
+func includesExtension(extensions map[string]any) ([]string, bool) {
+	extension, ok := extensions["x-gocart-includes"].([]any)
+	if !ok {
+		return nil, false
+	}
+	values := make([]string, 0, len(extension))
+	for _, value := range extension {
+		s, ok := value.(string)
+		if !ok {
+			return nil, false
+		}
+		values = append(values, s)
+	}
+	return values, true
+}
+
+// embeddedIncludePaths returns the paths of the relationships embedded in a resource, down to
+// depth levels of nesting: the properties of its _embedded object, followed by the ones embedded
+// in them, e.g. items and items.product
+func embeddedIncludePaths(schemaRef *openapi3.SchemaRef, prefix string, depth int) []string {
+	if depth <= 0 || schemaRef == nil || schemaRef.Value == nil {
+		return nil
+	}
+
+	// The relationships of allOf members are the resource's too
+	schemas := []*openapi3.Schema{schemaRef.Value}
+	for _, member := range schemaRef.Value.AllOf {
+		if member != nil && member.Value != nil {
+			schemas = append(schemas, member.Value)
+		}
+	}
+
+	var paths []string
+	for _, schema := range schemas {
+		embedded, ok := schema.Properties["_embedded"]
+		if !ok || embedded == nil || embedded.Value == nil {
+			continue
+		}
+		for _, name := range sortedKeys(embedded.Value.Properties) {
+			path := prefix + name
+			if contains(paths, path) {
+				continue
+			}
+			paths = append(paths, path)
+
+			related := embedded.Value.Properties[name]
+			if related != nil && related.Value != nil && related.Value.Type.Is("array") {
+				related = related.Value.Items
+			}
+			paths = append(paths, embeddedIncludePaths(related, path+".", depth-1)...)
+		}
+	}
+	return paths
+}
+
+// includeOption returns the include value of the SDK for a value of the spec, camelCasing each
+// segment of its path, e.g. line_items.product_variant becomes lineItems.productVariant
+func includeOption(value string) string {
+	segments := strings.Split(value, ".")
+	for i, segment := range segments {
+		segments[i] = toCamelCase(segment)
+	}
+	return strings.Join(segments, ".")
+}
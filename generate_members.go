@@ -0,0 +1,243 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// MemberDefinition represents a member of the SDK class. The members used by the generated
+// methods are shared with the resource sub-clients through the client interface of the SDK,
+// so they stay private to the SDK class.
+type MemberDefinition struct {
+	Name   string
+	Code   string // Declaration of the member, with its doc comment
+	Shared bool   // Used by the generated methods, shared with the resource sub-clients
+	Method bool
+	Type   string // Type of a shared field, or return type of a shared method
+	Params string // Parameters of a shared method, e.g. value: any
+}
+
+// sdkFields returns the fields of the SDK class
+func sdkFields(hasAuth, hasServerVariables bool) []MemberDefinition {
+	fields := []MemberDefinition{
+		{Name: "baseUrl", Code: "  private baseUrl: string;\n\n", Shared: true, Type: "string"},
+		{Name: "context", Code: "  public context: InMemoryContext;\n", Shared: true, Type: "InMemoryContext"},
+		{Name: "interceptors", Code: "  public interceptors: {\n" +
+			"    request: InterceptorManager<RequestInterceptor>;\n" +
+			"    response: InterceptorManager<ResponseInterceptor>;\n" +
+			"  };\n\n"},
+		{Name: "retryPolicy", Code: "  private retryPolicy: Required<RetryPolicy>;\n\n"},
+		{Name: "defaults", Code: "  private defaults: DefaultRequestOptions;\n\n", Shared: true, Type: "DefaultRequestOptions"},
+	}
+	if hasAuth {
+		fields = append(fields, MemberDefinition{Name: "auth", Code: "  private auth: AuthManager;\n\n", Shared: true, Type: "AuthManager"})
+	}
+	if hasServerVariables {
+		serverVariablesType := sdkTypeName("ServerVariables")
+		fields = append(fields, MemberDefinition{
+			Name:   "serverVariables",
+			Code:   fmt.Sprintf("  private serverVariables: %s;\n\n", serverVariablesType),
+			Shared: true,
+			Type:   serverVariablesType,
+		})
+	}
+	return fields
+}
+
+// sdkMethods returns the methods of the SDK class other than the ones of the operations
+func sdkMethods(hasAuth bool) []MemberDefinition {
+	var methods []MemberDefinition
+
+	if hasAuth {
+		var buf bytes.Buffer
+		buf.WriteString("  /**\n")
+		buf.WriteString("   * Replace the credentials used to authenticate requests\n")
+		buf.WriteString("   */\n")
+		buf.WriteString(fmt.Sprintf("  public setAuth(auth: %s): void {\n", sdkTypeName("Auth")))
+		buf.WriteString("    this.auth.setCredentials(auth);\n")
+		buf.WriteString("  }\n\n")
+		methods = append(methods, MemberDefinition{Name: "setAuth", Code: buf.String(), Method: true})
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("  /**\n")
+	buf.WriteString("   * Format filter values, converting camelCase strings to snake_case\n")
+	buf.WriteString("   * @private\n")
+	buf.WriteString("   */\n")
+	buf.WriteString("  private formatFilterValue(value: any): string {\n")
+	buf.WriteString("    let formattedValue = String(value);\n")
+	buf.WriteString("    // Convert camelCase string values to snake_case\n")
+	buf.WriteString("    if (typeof value === 'string' && formattedValue !== formattedValue.toLowerCase()) {\n")
+	buf.WriteString("      formattedValue = formattedValue.replace(/([a-z])([A-Z])/g, '$1_$2').toLowerCase();\n")
+	buf.WriteString("    }\n")
+	buf.WriteString("    return formattedValue;\n")
+	buf.WriteString("  }\n")
+	buf.WriteString("\n")
+	methods = append(methods, MemberDefinition{Name: "formatFilterValue", Code: buf.String(), Shared: true, Method: true, Type: "string", Params: "value: any"})
+
+	buf.Reset()
+	buf.WriteString("  /**\n")
+	buf.WriteString("   * Execute a request with interceptor support and retry capability\n")
+	buf.WriteString("   * @private\n")
+	buf.WriteString("   */\n")
+	buf.WriteString("  private async executeRequest(url: string, options: RequestInit, customFetch?: typeof fetch, interceptorRetries: number = 0): Promise<Response> {\n")
+	buf.WriteString("    if (interceptorRetries > MAX_INTERCEPTOR_RETRIES) {\n")
+	// The global Error, as a component schema named Error is imported by its bare name
+	buf.WriteString("      throw new globalThis.Error(`Request to ${url} was retried by interceptors more than ${MAX_INTERCEPTOR_RETRIES} times`);\n")
+	buf.WriteString("    }\n\n")
+	buf.WriteString("    let finalUrl = url;\n")
+	buf.WriteString("    let currentOptions = { ...options };\n")
+	buf.WriteString("\n")
+	buf.WriteString("    // Apply request interceptors\n")
+	buf.WriteString("    for (const interceptor of this.interceptors.request.interceptors) {\n")
+	buf.WriteString("      const result = await interceptor(currentOptions, finalUrl);\n")
+	buf.WriteString("      if (result) {\n")
+	buf.WriteString("        if (result.options) currentOptions = result.options;\n")
+	buf.WriteString("        if (result.url) finalUrl = result.url;\n")
+	buf.WriteString("      }\n")
+	buf.WriteString("    }\n")
+	buf.WriteString("\n")
+	buf.WriteString("    // Make the request, retrying transient failures\n")
+	buf.WriteString("    let response = await this.fetchWithRetry(finalUrl, currentOptions, customFetch);\n")
+	buf.WriteString("\n")
+	buf.WriteString("    // Apply response interceptors\n")
+	buf.WriteString("    for (const interceptor of this.interceptors.response.interceptors) {\n")
+	buf.WriteString("      const result = await interceptor(response, currentOptions, finalUrl);\n")
+	buf.WriteString("      if (result) {\n")
+	buf.WriteString("        // Check if the interceptor returned a retry request\n")
+	buf.WriteString("        if (this.isRetryRequest(result)) {\n")
+	buf.WriteString("          // Recursively execute the retry request\n")
+	buf.WriteString("          return this.executeRequest(result.url, result.options, customFetch, interceptorRetries + 1);\n")
+	buf.WriteString("        } else {\n")
+	buf.WriteString("          // Replace the response with the modified one\n")
+	buf.WriteString("          response = result;\n")
+	buf.WriteString("        }\n")
+	buf.WriteString("      }\n")
+	buf.WriteString("    }\n")
+	buf.WriteString("\n")
+	buf.WriteString("    return response;\n")
+	buf.WriteString("  }\n")
+	buf.WriteString("\n")
+	methods = append(methods, MemberDefinition{
+		Name:   "executeRequest",
+		Code:   buf.String(),
+		Shared: true,
+		Method: true,
+		Type:   "Promise<Response>",
+		Params: "url: string, options: RequestInit, customFetch?: typeof fetch",
+	})
+
+	buf.Reset()
+	buf.WriteString("  /**\n")
+	buf.WriteString("   * Send a request, retrying transient failures according to the retry policy\n")
+	buf.WriteString("   * @private\n")
+	buf.WriteString("   */\n")
+	buf.WriteString("  private async fetchWithRetry(url: string, options: RequestInit, customFetch?: typeof fetch): Promise<Response> {\n")
+	buf.WriteString("    const method = options.method ?? 'GET';\n")
+	buf.WriteString("    const fetchImpl = customFetch ?? fetch;\n")
+	buf.WriteString("    for (let attempt = 1; ; attempt++) {\n")
+	buf.WriteString("      let response: Response;\n")
+	buf.WriteString("      try {\n")
+	buf.WriteString("        response = await fetchImpl(url, options);\n")
+	buf.WriteString("      } catch (error) {\n")
+	buf.WriteString("        if (!shouldRetry(this.retryPolicy, method, attempt, undefined, error)) {\n")
+	buf.WriteString("          throw error;\n")
+	buf.WriteString("        }\n")
+	buf.WriteString("        await sleep(retryDelay(this.retryPolicy, attempt), options.signal);\n")
+	buf.WriteString("        continue;\n")
+	buf.WriteString("      }\n\n")
+	buf.WriteString("      if (!shouldRetry(this.retryPolicy, method, attempt, response)) {\n")
+	buf.WriteString("        return response;\n")
+	buf.WriteString("      }\n")
+	buf.WriteString("      // Release the connection of the discarded response\n")
+	buf.WriteString("      response.body?.cancel().catch(() => undefined);\n")
+	buf.WriteString("      await sleep(retryDelay(this.retryPolicy, attempt, response), options.signal);\n")
+	buf.WriteString("    }\n")
+	buf.WriteString("  }\n")
+	buf.WriteString("\n")
+	methods = append(methods, MemberDefinition{Name: "fetchWithRetry", Code: buf.String(), Method: true})
+
+	buf.Reset()
+	buf.WriteString("  /**\n")
+	buf.WriteString("   * Type guard to check if the result is a RetryRequest\n")
+	buf.WriteString("   * @private\n")
+	buf.WriteString("   */\n")
+	buf.WriteString("  private isRetryRequest(result: Response | RetryRequest): result is RetryRequest {\n")
+	buf.WriteString("    return (result as RetryRequest).url !== undefined && (result as RetryRequest).options !== undefined;\n")
+	buf.WriteString("  }\n")
+	buf.WriteString("\n")
+	methods = append(methods, MemberDefinition{Name: "isRetryRequest", Code: buf.String(), Method: true})
+
+	return methods
+}
+
+// memberNames returns the names of members of the SDK class, along with its constructor
+func memberNames(members []MemberDefinition) []string {
+	names := []string{"constructor"}
+	for _, member := range members {
+		names = append(names, member.Name)
+	}
+	return names
+}
+
+// clientInterfaceName returns the name of the interface sharing the members of the SDK with the
+// resource sub-clients, e.g. GoCartSDKClient
+func clientInterfaceName() string {
+	return sdkTypeName("Client")
+}
+
+// paramNames returns the names of a list of parameters, e.g. url, customFetch for
+// url: string, customFetch?: typeof fetch
+func paramNames(params string) string {
+	var names []string
+	for _, param := range strings.Split(params, ", ") {
+		name, _, _ := strings.Cut(param, ":")
+		names = append(names, strings.TrimSuffix(name, "?"))
+	}
+	return strings.Join(names, ", ")
+}
+
+// generateClientInterface generates the interface through which the resource sub-clients use
+// the shared members of the SDK
+func generateClientInterface(members []MemberDefinition) string {
+	var buf bytes.Buffer
+	buf.WriteString("/**\n")
+	buf.WriteString(" * Members of the SDK used by the resource sub-clients\n")
+	buf.WriteString(" */\n")
+	buf.WriteString(fmt.Sprintf("interface %s {\n", clientInterfaceName()))
+	for _, member := range members {
+		if !member.Shared {
+			continue
+		}
+		if member.Method {
+			buf.WriteString(fmt.Sprintf("  %s(%s): %s;\n", member.Name, member.Params, member.Type))
+		} else {
+			buf.WriteString(fmt.Sprintf("  readonly %s: %s;\n", member.Name, member.Type))
+		}
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+// generateClientObject generates the constructor statements creating the client of the
+// resource sub-clients, reading the shared members of the SDK as they are when used
+func generateClientObject(members []MemberDefinition) string {
+	var buf bytes.Buffer
+	buf.WriteString("    const sdk = this;\n")
+	buf.WriteString(fmt.Sprintf("    const client: %s = {\n", clientInterfaceName()))
+	for _, member := range members {
+		if !member.Shared {
+			continue
+		}
+		if member.Method {
+			buf.WriteString(fmt.Sprintf("      %s: (%s) => sdk.%s(%s),\n", member.Name, member.Params, member.Name, paramNames(member.Params)))
+		} else {
+			buf.WriteString(fmt.Sprintf("      get %s() {\n", member.Name))
+			buf.WriteString(fmt.Sprintf("        return sdk.%s;\n", member.Name))
+			buf.WriteString("      },\n")
+		}
+	}
+	buf.WriteString("    };\n")
+	return buf.String()
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// PaginationDefinition describes how a list operation is walked page by page
+type PaginationDefinition struct {
+	Strategy      string // page, cursor or link
+	ItemsProperty string // Response property holding the items of a page, e.g. data
+	ItemType      string
+}
+
+// paginationStrategies are the strategies supported by the pagination runtime
+var paginationStrategies = map[string]bool{
+	"page":   true,
+	"cursor": true,
+	"link":   true,
+}
+
+// getPaginationDefinition returns the pagination of a list operation, or nil if the operation
+// cannot be paginated. The strategy is read from the x-gocart-pagination extension, either a
+// strategy name or an object with strategy and items keys, and otherwise derived from the
+// page[number] (page) or page[cursor]/page[after] (cursor) query parameters.
+func getPaginationDefinition(methodDefinition MethodDefinition) *PaginationDefinition {
+	if !methodDefinition.Arguments.HasParam("params") {
+		return nil
+	}
+	if methodDefinition.ResponseContentType != "application/json" {
+		return nil
+	}
+
+	hasQueryParam := func(name string) bool {
+		for _, qp := range methodDefinition.QueryParams["query"] {
+			if qp.Name == name {
+				return true
+			}
+		}
+		return false
+	}
+
+	pagination := &PaginationDefinition{ItemsProperty: "data"}
+	switch extension := methodDefinition.OperationRef.Extensions["x-gocart-pagination"].(type) {
+	case string:
+		pagination.Strategy = extension
+	case bool:
+		if !extension {
+			return nil
+		}
+	case map[string]any:
+		if strategy, ok := extension["strategy"].(string); ok {
+			pagination.Strategy = strategy
+		}
+		if items, ok := extension["items"].(string); ok && items != "" {
+			pagination.ItemsProperty = items
+		}
+	}
+
+	if pagination.Strategy == "" {
+		if !strings.HasPrefix(methodDefinition.Name, "list") {
+			return nil
+		}
+		switch {
+		case hasQueryParam("page[number]"):
+			pagination.Strategy = "page"
+		case hasQueryParam("page[cursor]"), hasQueryParam("page[after]"):
+			pagination.Strategy = "cursor"
+		default:
+			return nil
+		}
+	}
+
+	// Unknown strategies (e.g. none) disable pagination, and the page strategy needs a page number
+	if !paginationStrategies[pagination.Strategy] {
+		return nil
+	}
+	if pagination.Strategy == "page" && !hasQueryParam("page[number]") {
+		return nil
+	}
+
+	pagination.ItemType = paginationItemType(methodDefinition.ResponseTypeRef, pagination.ItemsProperty)
+	return pagination
+}
+
+// paginationItemType returns the type of the items of a page, when the response declares them
+// as an array property
+func paginationItemType(schemaRef *openapi3.SchemaRef, itemsProperty string) string {
+	if schemaRef == nil || schemaRef.Value == nil {
+		return "any"
+	}
+	itemsSchema, ok := schemaRef.Value.Properties[itemsProperty]
+	if !ok || itemsSchema.Value == nil || !itemsSchema.Value.Type.Is("array") || itemsSchema.Value.Items == nil {
+		return "any"
+	}
+	if itemsSchema.Value.Items.Ref != "" {
+		return toPascalCase(getRefName(itemsSchema.Value.Items.Ref))
+	}
+	return resolveInlineType(itemsSchema.Value.Items)
+}
+
+// paginationMethodNames returns the names of the iterator and collecting methods of a list
+// operation, e.g. listProducts -> iterateProducts, listProductsAll
+func paginationMethodNames(methodDefinition MethodDefinition) (string, string) {
+	name := methodDefinition.MethodName()
+	iterateName := "iterate" + toPascalCase(strings.TrimPrefix(name, "list"))
+	if !strings.HasPrefix(name, "list") {
+		iterateName = "iterate" + toPascalCase(name)
+	}
+	return iterateName, name + "All"
+}
+
+// generatePaginationMethods generates the page fetcher, the async iterator and the collecting
+// method of a paginated list operation
+func generatePaginationMethods(methodDefinition MethodDefinition) string {
+	pagination := methodDefinition.Pagination
+	iterateName, allName := paginationMethodNames(methodDefinition)
+	pageName := methodDefinition.Name + "Page"
+
+	var buf bytes.Buffer
+
+	var argsSignature, argsDoc, argNames, pageArgs []string
+	for _, p := range methodDefinition.Arguments {
+		argsDoc = append(argsDoc, fmt.Sprintf("@param %s %s", p.Name, p.Type.Name))
+		argNames = append(argNames, p.Name)
+		if p.Type.Optional {
+			argsSignature = append(argsSignature, fmt.Sprintf("%s: %s = {}", p.Name, p.Type.Name))
+		} else {
+			argsSignature = append(argsSignature, fmt.Sprintf("%s: %s", p.Name, p.Type.Name))
+		}
+
+		switch {
+		case p.Name == "params" && pagination.Strategy == "page":
+			pageArgs = append(pageArgs, "{ ...params, page: { ...params.page, number: cursor.number } }")
+		default:
+			pageArgs = append(pageArgs, p.Name)
+		}
+	}
+
+	var throwsDoc []string
+	if len(methodDefinition.ErrorResponses) > 0 {
+		throwsDoc = append(throwsDoc, fmt.Sprintf("@throws {%s}", errorUnionName(methodDefinition.Name)))
+	}
+
+	// Page fetcher, keeping the raw response to find the next page
+	var pageSignature []string
+	for _, p := range methodDefinition.Arguments {
+		pageSignature = append(pageSignature, fmt.Sprintf("%s: %s", p.Name, p.Type.Name))
+	}
+	pageSignature = append(pageSignature, "pageUrl?: string", "options?: RequestOptions")
+
+	buf.WriteString(renderJSDoc([]string{
+		fmt.Sprintf("Fetch a page of %s, keeping the response to find the next page", methodDefinition.Name),
+		"@private",
+	}, "  "))
+	buf.WriteString(fmt.Sprintf("  private async %s(%s): Promise<Page<%s>> {\n", pageName, strings.Join(pageSignature, ", "), pagination.ItemType))
+	// The timeout covers reading the body, so it is not handed to the raw request
+	var body bytes.Buffer
+	rawArgs := append(argNames, "{ ...callOptions, signal: timeout.signal, timeoutMs: undefined, raw: true, pageUrl }")
+	body.WriteString(fmt.Sprintf("    const response = await this.%s(%s);\n", methodDefinition.MethodName(), strings.Join(rawArgs, ", ")))
+	body.WriteString(generateErrorHandling())
+	body.WriteString("    const data = toClientType(await response.json());\n")
+	body.WriteString(fmt.Sprintf("    return { items: data?.%s ?? [], body: data, response };\n", toCamelCase(pagination.ItemsProperty)))
+	buf.WriteString(wrapRequestBody(body.String()))
+	buf.WriteString("  }\n\n")
+
+	// Async iterator over the items of all pages
+	docLines := []string{fmt.Sprintf("Iterate over the items of all pages of %s, fetching pages on demand", methodDefinition.Name)}
+	docLines = append(docLines, argsDoc...)
+	docLines = append(docLines, "@param options Request options and maximum number of items")
+	docLines = append(docLines, fmt.Sprintf("@returns AsyncGenerator<%s>", pagination.ItemType))
+	docLines = append(docLines, throwsDoc...)
+	buf.WriteString(renderJSDoc(docLines, "  "))
+
+	startPage := "undefined"
+	if pagination.Strategy == "page" {
+		startPage = "params.page?.number"
+	}
+	buf.WriteString(fmt.Sprintf("  public async *%s(%s): AsyncGenerator<%s, void, undefined> {\n", iterateName, strings.Join(append(argsSignature, "options: PaginationOptions = {}"), ", "), pagination.ItemType))
+	buf.WriteString(fmt.Sprintf("    yield* paginate<%s>(\n", pagination.ItemType))
+	buf.WriteString(fmt.Sprintf("      '%s',\n", pagination.Strategy))
+	buf.WriteString(fmt.Sprintf("      (cursor) => this.%s(%s),\n", pageName, strings.Join(append(pageArgs, "cursor.url", "options"), ", ")))
+	buf.WriteString("      options,\n")
+	buf.WriteString(fmt.Sprintf("      %s,\n", startPage))
+	buf.WriteString("    );\n")
+	buf.WriteString("  }\n\n")
+
+	// Collect the items of all pages
+	docLines = []string{fmt.Sprintf("Fetch the items of all pages of %s", methodDefinition.Name)}
+	docLines = append(docLines, argsDoc...)
+	docLines = append(docLines, "@param options Request options and maximum number of items")
+	docLines = append(docLines, fmt.Sprintf("@returns Promise<%s[]>", wrapUnion(pagination.ItemType)))
+	docLines = append(docLines, throwsDoc...)
+	buf.WriteString(renderJSDoc(docLines, "  "))
+	buf.WriteString(fmt.Sprintf("  public async %s(%s): Promise<%s[]> {\n", allName, strings.Join(append(argsSignature, "options: PaginationOptions = {}"), ", "), wrapUnion(pagination.ItemType)))
+	buf.WriteString(fmt.Sprintf("    return collect(this.%s(%s));\n", iterateName, strings.Join(append(argNames, "options"), ", ")))
+	buf.WriteString("  }\n")
+
+	return buf.String()
+}
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"sort"
 	"strings"
-	"unicode"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
@@ -23,25 +22,27 @@ func getParamDefinitions(doc *openapi3.T) []ParamDefinition {
 	for _, path := range doc.Paths.InMatchingOrder() {
 		pathItem := doc.Paths.Find(path)
 
-		// Iterate over all operations in the path
-		operations := map[string]*openapi3.Operation{
-			"GET": pathItem.Get,
-		}
-
-		for method, operation := range operations {
+		// Iterate over the operations in a stable order
+		for _, method := range supportedMethods {
+			operation := pathItem.GetOperation(method)
 			if operation == nil {
 				continue
 			}
 
-			// Determine a unique interface name
-			interfaceName := generateInterfaceName(method, path, operation.OperationID)
-
-			// Extract query parameters
+			// Header and cookie parameters are grouped on the params object along with the query
+			// parameters; operations other than GET only have params when they declare any
 			queryParams := extractQueryParameters(operation)
+			inputParams := extractInputParameters(pathItem, operation)
+			if method != "GET" && len(queryParams) == 0 && len(inputParams) == 0 {
+				continue
+			}
+
+			// Determine a unique interface name
+			interfaceName := generateInterfaceName(generateMethodName(operation, method, path))
 
 			paramDefs = append(paramDefs, ParamDefinition{
 				Name:      interfaceName,
-				Params:    queryParams,
+				Params:    withSparseFields(doc, append(queryParams, inputParams...)),
 				Operation: operation,
 			})
 		}
@@ -129,7 +130,8 @@ func generateParams(doc *openapi3.T, paramDefs []ParamDefinition) []byte {
 		tsBuffer.WriteString("\n")
 	}
 
-	// Sort additional types to ensure consistent output
+	// Sort additional types to ensure consistent output, field unions being shared by operations
+	allAdditionalTypes = removeDuplicates(allAdditionalTypes)
 	sort.Strings(allAdditionalTypes)
 	for _, tsType := range allAdditionalTypes {
 		tsBuffer.WriteString(tsType + "\n\n")
@@ -188,21 +190,9 @@ func generateParams(doc *openapi3.T, paramDefs []ParamDefinition) []byte {
 // 	return tsBuffer.Bytes()
 // }
 
-// generateInterfaceName creates a unique and descriptive TypeScript interface name
-func generateInterfaceName(method, path, operationID string) string {
-	if operationID != "" {
-		return toPascalCase(operationID) + "Params"
-	}
-
-	// Replace non-alphanumeric characters with underscores
-	cleanPath := strings.Map(func(r rune) rune {
-		if unicode.IsLetter(r) || unicode.IsDigit(r) {
-			return r
-		}
-		return '_'
-	}, path)
-
-	return toPascalCase(method) + toPascalCase(cleanPath) + "Params"
+// generateInterfaceName creates the name of the params interface of an operation from its method name
+func generateInterfaceName(methodName string) string {
+	return toPascalCase(methodName) + "Params"
 }
 
 // extractQueryParameters extracts query parameters from an operation
@@ -221,29 +211,132 @@ func extractQueryParameters(operation *openapi3.Operation) []QueryParameter {
 				}
 			}
 
-			params = append(params, QueryParameter{
-				Name:        param.Name,
-				In:          param.In,
-				Description: param.Description,
-				Schema:      param.Schema,
-				Required:    param.Required,
-				SDKType:     sdkType,
-			})
+			params = append(params, newQueryParameter(param, sdkType))
+		}
+	}
+	return params
+}
+
+// ignoredHeaderParameters are the header parameters OpenAPI tells to ignore, the SDK setting these headers itself
+var ignoredHeaderParameters = []string{"accept", "authorization", "content-type"}
+
+// extractInputParameters extracts the header and cookie parameters of an operation, including
+// those declared on its path. Operation parameters override path parameters with the same name.
+func extractInputParameters(pathItem *openapi3.PathItem, operation *openapi3.Operation) []QueryParameter {
+	var params []QueryParameter
+	index := make(map[string]int)
+	for _, declared := range []openapi3.Parameters{pathItem.Parameters, operation.Parameters} {
+		for _, paramRef := range declared {
+			if paramRef == nil || paramRef.Value == nil {
+				continue
+			}
+			param := paramRef.Value
+			if param.In != openapi3.ParameterInHeader && param.In != openapi3.ParameterInCookie {
+				continue
+			}
+			if param.In == openapi3.ParameterInHeader && contains(ignoredHeaderParameters, strings.ToLower(param.Name)) {
+				continue
+			}
+
+			p := newQueryParameter(param, "")
+			key := param.In + ":" + strings.ToLower(param.Name)
+			if i, ok := index[key]; ok {
+				params[i] = p
+				continue
+			}
+			index[key] = len(params)
+			params = append(params, p)
 		}
 	}
 	return params
 }
 
+// isPlainParamGroup reports whether a group of parameters is a single parameter without nested
+// structure, other than sort and include which are typed from their values
+func isPlainParamGroup(groupName string, group []QueryParameter) bool {
+	return groupName != "include" && groupName != "sort" && len(group) == 1 && group[0].Name == groupName
+}
+
+// generatePlainParam generates the property of a plain query parameter in a params interface
+func generatePlainParam(param QueryParameter, doc *openapi3.T, additionalTypes *[]string) string {
+	var buf bytes.Buffer
+
+	description := param.Description
+	if description == "" {
+		description = fmt.Sprintf("%s for the API.", strings.Title(param.Name))
+	}
+	buf.WriteString(fmt.Sprintf("  /**\n   * %s\n   */\n", description))
+
+	tsType := param.SDKType
+	if tsType == "" {
+		var additional []string
+		tsType, additional = resolveType(param.Schema, doc)
+		*additionalTypes = append(*additionalTypes, additional...)
+	}
+	if param.Schema != nil && param.Schema.Value != nil && param.Schema.Value.Nullable {
+		tsType = fmt.Sprintf("%s | null", tsType)
+	}
+
+	optional := "?:"
+	if param.Required {
+		optional = ":"
+	}
+	buf.WriteString(fmt.Sprintf("  %s%s %s;\n\n", propertyKey(toCamelCase(param.Name)), optional, indentType(tsType, "  ")))
+	return buf.String()
+}
+
+// paramsRequired reports whether the params argument of an operation is required: one of its
+// plain query, header or cookie parameters is, grouped query parameters being always optional
+func paramsRequired(params []QueryParameter) bool {
+	for _, param := range params {
+		if !param.Required {
+			continue
+		}
+		if param.In != openapi3.ParameterInQuery || (!isBracketParam(param.Name) && param.Name != "include" && param.Name != "sort") {
+			return true
+		}
+	}
+	return false
+}
+
+// hasRequiredParameter reports whether one of the parameters is required
+func hasRequiredParameter(params []QueryParameter) bool {
+	for _, param := range params {
+		if param.Required {
+			return true
+		}
+	}
+	return false
+}
+
 // generateTypeScriptInterface generates the TypeScript interface for parameters
 func generateTypeScriptInterface(interfaceName string, params []QueryParameter, operation *openapi3.Operation, doc *openapi3.T) (string, []string) {
 	var buf bytes.Buffer
 	var additionalTypes []string
 
-	// Start interface
-	buf.WriteString(fmt.Sprintf("export interface %s {\n", interfaceName))
+	// Operations narrowing their response by a sparse fieldset take its fields as type parameter
+	sparseFields := getSparseFieldsDefinition(doc, operation, params)
+	if sparseFields != nil {
+		buf.WriteString(fmt.Sprintf("export interface %s<%s> {\n", interfaceName, sparseFields.typeParameter()))
+	} else {
+		buf.WriteString(fmt.Sprintf("export interface %s {\n", interfaceName))
+	}
+
+	// Header and cookie parameters have their own groups
+	var queryParams, headerParams, cookieParams []QueryParameter
+	for _, param := range params {
+		switch param.In {
+		case openapi3.ParameterInHeader:
+			headerParams = append(headerParams, param)
+		case openapi3.ParameterInCookie:
+			cookieParams = append(cookieParams, param)
+		default:
+			queryParams = append(queryParams, param)
+		}
+	}
 
 	// Group parameters by their base (e.g., filter, page)
-	groupedParams := groupParameters(params)
+	groupedParams := groupParameters(queryParams)
 
 	// Sort the group names for consistent output
 	groupNames := make([]string, 0, len(groupedParams))
@@ -256,6 +349,12 @@ func generateTypeScriptInterface(interfaceName string, params []QueryParameter,
 	for _, groupName := range groupNames {
 		group := groupedParams[groupName]
 
+		// Plain parameters are typed as they are, e.g. q?: string
+		if isPlainParamGroup(groupName, group) {
+			buf.WriteString(generatePlainParam(group[0], doc, &additionalTypes))
+			continue
+		}
+
 		// Generate comments
 		groupDesc := fmt.Sprintf("%s for the API.", strings.Title(groupName))
 		buf.WriteString(fmt.Sprintf("  /**\n   * %s\n   */\n", groupDesc))
@@ -268,10 +367,13 @@ func generateTypeScriptInterface(interfaceName string, params []QueryParameter,
 			// Handle enumerated types with prefix based on interface name
 			enumTypeName := interfaceName + toPascalCase(groupName) + "Option"
 
-			enumValues := extractEnumValues(groupName, group)
+			enumValues := extractEnumValues(groupName, group, operation, doc)
 			if len(enumValues) > 0 {
-				// Create TypeScript type for enum
+				// Create TypeScript type for enum, include ones being imported by the SDK to narrow responses
 				tsType := fmt.Sprintf("type %s = %s;", enumTypeName, strings.Join(enumValues, " | "))
+				if groupName == "include" {
+					tsType = "export " + tsType
+				}
 				additionalTypes = append(additionalTypes, tsType)
 				// Define the property as an array of the enum type
 				nestedType = fmt.Sprintf("%s[]", enumTypeName)
@@ -279,6 +381,10 @@ func generateTypeScriptInterface(interfaceName string, params []QueryParameter,
 				// Fallback to string array if no enum values are found
 				nestedType = "string[]"
 			}
+		} else if groupName == "fields" {
+			// Sparse fieldsets are keyed by resource type
+			nestedType, nestedAdditionalTypes = generateFieldsInterface(group, sparseFields, doc)
+			additionalTypes = append(additionalTypes, nestedAdditionalTypes...)
 		} else {
 			// Handle nested objects like filter and page
 			nestedType, nestedAdditionalTypes = generateNestedInterface(strings.Title(groupName), group, doc)
@@ -289,7 +395,29 @@ func generateTypeScriptInterface(interfaceName string, params []QueryParameter,
 		buf.WriteString(fmt.Sprintf("  %s?: %s;\n\n", toCamelCase(groupName), nestedType))
 	}
 
-	if strings.HasPrefix(operation.OperationID, "list") {
+	for _, group := range []struct {
+		name        string
+		description string
+		params      []QueryParameter
+	}{
+		{"headers", "Headers of the request.", headerParams},
+		{"cookies", "Cookies of the request.", cookieParams},
+	} {
+		if len(group.params) == 0 {
+			continue
+		}
+		buf.WriteString(fmt.Sprintf("  /**\n   * %s\n   */\n", group.description))
+		nestedType, nestedAdditionalTypes := generateInputInterface(group.params, doc)
+		additionalTypes = append(additionalTypes, nestedAdditionalTypes...)
+		optional := "?:"
+		if hasRequiredParameter(group.params) {
+			optional = ":"
+		}
+		buf.WriteString(fmt.Sprintf("  %s%s %s;\n\n", group.name, optional, nestedType))
+	}
+
+	// The SDK sends the count header for list methods, named after the method like the interface
+	if strings.HasPrefix(interfaceName, "List") {
 		// Add to main interface
 		buf.WriteString("  /**\n")
 		buf.WriteString("   * Include the count of total items in the collection.\n")
@@ -308,18 +436,12 @@ func groupParameters(params []QueryParameter) map[string][]QueryParameter {
 	grouped := make(map[string][]QueryParameter)
 	for _, param := range params {
 		// Check if parameter name has a nested structure like filter[id]
-		if strings.Contains(param.Name, "[") && strings.HasSuffix(param.Name, "]") {
-			base := param.Name[:strings.Index(param.Name, "[")]
-			nested := param.Name[strings.Index(param.Name, "[")+1 : len(param.Name)-1]
-			// Create a new parameter with base as group and nested name
-			grouped[base] = append(grouped[base], QueryParameter{
-				Name:        nested,
-				In:          param.In,
-				Description: param.Description,
-				Schema:      param.Schema,
-				Required:    param.Required,
-				SDKType:     param.SDKType,
-			})
+		if isBracketParam(param.Name) {
+			base, nested := splitBracketParam(param.Name)
+			// Copy the parameter with base as group and nested name
+			nestedParam := param
+			nestedParam.Name = nested
+			grouped[base] = append(grouped[base], nestedParam)
 		} else {
 			// Treat as top-level parameter
 			grouped[param.Name] = append(grouped[param.Name], param)
@@ -328,8 +450,19 @@ func groupParameters(params []QueryParameter) map[string][]QueryParameter {
 	return grouped
 }
 
+// isBracketParam reports whether a parameter name has a nested structure like filter[id]
+func isBracketParam(name string) bool {
+	return strings.Contains(name, "[") && strings.HasSuffix(name, "]")
+}
+
+// splitBracketParam splits a nested parameter name like fields[product] into its group and key
+func splitBracketParam(name string) (base, key string) {
+	open := strings.Index(name, "[")
+	return name[:open], name[open+1 : len(name)-1]
+}
+
 // extractEnumValues extracts enum values for specific groups
-func extractEnumValues(groupName string, params []QueryParameter) []string {
+func extractEnumValues(groupName string, params []QueryParameter, operation *openapi3.Operation, doc *openapi3.T) []string {
 	var enumValues []string
 	for _, param := range params {
 		if param.Schema != nil && len(param.Schema.Value.Enum) > 0 {
@@ -338,11 +471,10 @@ func extractEnumValues(groupName string, params []QueryParameter) []string {
 					enumValues = append(enumValues, fmt.Sprintf(`'%s'`, toCamelCase(strVal)))
 				}
 			}
-		} else {
-			// TODO: fix this
-			// Handle 'include' parameter without explicit enum by inferring from predefined values
-			if groupName == "include" {
-				enumValues = []string{"'parent'", "'parents'", "'children'", "'attributes'"}
+		} else if groupName == "include" {
+			// Include values without enum are declared by an extension or derived from the response
+			for _, value := range includeValues(operation, doc) {
+				enumValues = append(enumValues, quoteLiteral(includeOption(value)))
 			}
 		}
 	}
@@ -388,7 +520,36 @@ func generateNestedInterface(name string, params []QueryParameter, doc *openapi3
 		}
 
 		// Add to buffer with correct formatting
-		buf.WriteString(fmt.Sprintf("    %s%s %s;\n\n", toCamelCase(param.Name), optional, tsType))
+		buf.WriteString(fmt.Sprintf("    %s%s %s;\n\n", toCamelCase(param.Name), optional, indentType(tsType, "    ")))
+	}
+	buf.WriteString("  }")
+
+	return buf.String(), additionalTypes
+}
+
+// generateInputInterface generates the TypeScript type of header or cookie parameters, keyed by
+// the camelCase form of their names (e.g. Accept-Language becomes acceptLanguage)
+func generateInputInterface(params []QueryParameter, doc *openapi3.T) (string, []string) {
+	var buf bytes.Buffer
+	var additionalTypes []string
+
+	buf.WriteString("{\n")
+	for _, param := range params {
+		if param.Description != "" {
+			buf.WriteString(fmt.Sprintf("    /**\n     * %s\n     */\n", param.Description))
+		}
+
+		tsType, additional := resolveType(param.Schema, doc)
+		additionalTypes = append(additionalTypes, additional...)
+		if param.Schema != nil && param.Schema.Value != nil && param.Schema.Value.Nullable {
+			tsType = fmt.Sprintf("%s | null", tsType)
+		}
+
+		optional := "?:"
+		if param.Required {
+			optional = ":"
+		}
+		buf.WriteString(fmt.Sprintf("    %s%s %s;\n\n", propertyKey(headerPropertyName(param.Name)), optional, indentType(tsType, "    ")))
 	}
 	buf.WriteString("  }")
 
@@ -425,18 +586,46 @@ func resolveType(schemaRef *openapi3.SchemaRef, doc *openapi3.T) (string, []stri
 		return tsType, additionalTypes
 	}
 
-	// Handle anyOf (union types)
-	if schema.AnyOf != nil && len(schema.AnyOf) > 0 {
-		tsTypes := []string{}
-		for _, anyOfSchema := range schema.AnyOf {
-			anyOfType, additional := resolveType(anyOfSchema, doc)
+	// Handle allOf (intersection types)
+	if len(schema.AllOf) > 0 {
+		tsType, err := resolveAllOf(schema, doc)
+		if err != nil {
+			return "any", additionalTypes
+		}
+		return tsType, additionalTypes
+	}
+
+	// Handle oneOf and anyOf (union types, tagged when a discriminator is set)
+	if len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 {
+		members := schema.OneOf
+		if len(members) == 0 {
+			members = schema.AnyOf
+		}
+		tsType := resolveUnion(members, schema.Discriminator, func(member *openapi3.SchemaRef) string {
+			memberType, additional := resolveType(member, doc)
 			additionalTypes = append(additionalTypes, additional...)
-			tsTypes = append(tsTypes, anyOfType)
+			return memberType
+		})
+		return tsType, additionalTypes
+	}
+
+	// Handle maps (additionalProperties without declared properties)
+	if len(schema.Properties) == 0 {
+		if mapType, ok := resolveMapType(schema, doc); ok {
+			return mapType, additionalTypes
+		}
+	}
+
+	// Handle inline objects as structural types, recursively
+	if len(schema.Properties) > 0 && (schema.Type == nil || schema.Type.Includes("object")) {
+		props, err := collectProperties([]*openapi3.Schema{schema}, schema.Required, doc)
+		if err == nil {
+			indexType := ""
+			if additionalType, ok := additionalPropertiesType(schema, doc); ok {
+				indexType = indexSignatureType(additionalType, props)
+			}
+			return renderObjectLiteral(props, indexType), additionalTypes
 		}
-		// Remove duplicate types
-		tsTypes = removeDuplicates(tsTypes)
-		// Join with | for union types
-		return strings.Join(tsTypes, " | "), additionalTypes
 	}
 
 	// Handle multiple types (union types)
@@ -450,7 +639,7 @@ func resolveType(schemaRef *openapi3.SchemaRef, doc *openapi3.T) (string, []stri
 						// Recursively resolve the type of array items
 						itemType, additional := resolveType(schema.Items, doc)
 						additionalTypes = append(additionalTypes, additional...)
-						return fmt.Sprintf("%s[]", itemType), additionalTypes
+						return fmt.Sprintf("%s[]", wrapUnion(itemType)), additionalTypes
 					}
 
 					// Handle special formats
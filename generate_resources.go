@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ResourceDefinition represents a group of operations exposed as a sub-client of the SDK,
+// e.g. sdk.products.list()
+type ResourceDefinition struct {
+	Name         string // Resource name, from the x-gocart-resource extension or the first tag
+	PropertyName string // Property holding the sub-client on the SDK, e.g. products
+	ClassName    string // Sub-client class name, e.g. ProductsApi
+	Methods      MethodDefinitions
+}
+
+// getOperationResource returns the resource an operation belongs to: the x-gocart-resource
+// extension of the operation or its path, or else the first OpenAPI tag
+func getOperationResource(pathItem *openapi3.PathItem, operation *openapi3.Operation) string {
+	for _, extensions := range []map[string]any{operation.Extensions, pathItem.Extensions} {
+		if resource, ok := extensions["x-gocart-resource"].(string); ok && resource != "" {
+			return resource
+		}
+	}
+	if len(operation.Tags) > 0 {
+		return operation.Tags[0]
+	}
+	return ""
+}
+
+// groupMethodsByResource splits method definitions into resource sub-clients and the methods
+// that stay on the SDK class itself (operations without a resource). The sub-clients are named
+// apart from the given members of the SDK class.
+func groupMethodsByResource(methodDefinitions MethodDefinitions, sdkMembers []string) ([]ResourceDefinition, MethodDefinitions) {
+	resourcesByName := make(map[string]*ResourceDefinition)
+	var rootMethods MethodDefinitions
+
+	for _, m := range methodDefinitions {
+		if m.Resource == "" {
+			rootMethods = append(rootMethods, m)
+			continue
+		}
+
+		resource, ok := resourcesByName[m.Resource]
+		if !ok {
+			resource = &ResourceDefinition{Name: m.Resource}
+			resourcesByName[m.Resource] = resource
+		}
+		resource.Methods = append(resource.Methods, m)
+	}
+
+	// Sub-clients are properties of the SDK class next to its own members and root methods
+	takenProperties := make(map[string]bool)
+	for _, name := range sdkMembers {
+		takenProperties[name] = true
+	}
+	for _, m := range rootMethods {
+		for _, name := range methodMemberNames(m) {
+			takenProperties[name] = true
+		}
+	}
+	takenClasses := map[string]bool{generatorOptions.ClassName: true, clientInterfaceName(): true, "ApiResource": true}
+
+	var resources []ResourceDefinition
+	for _, name := range sortedKeys(resourcesByName) {
+		resource := resourcesByName[name]
+		resource.PropertyName, resource.ClassName = resourceNames(resource.Name, sdkMembers, takenProperties, takenClasses)
+		takenProperties[resource.PropertyName] = true
+		takenClasses[resource.ClassName] = true
+
+		// Method names are derived from the operation name without the resource,
+		// unless that makes them collide within the resource
+		used := make(map[string]int)
+		for _, m := range resource.Methods {
+			used[resourceMethodName(m.Name, resource.Name)]++
+		}
+		for i, m := range resource.Methods {
+			name := resourceMethodName(m.Name, resource.Name)
+			if used[name] > 1 {
+				name = m.Name
+			}
+			resource.Methods[i].ClientName = name
+		}
+		resource.Methods.Sort()
+
+		resources = append(resources, *resource)
+	}
+
+	sort.Slice(resources, func(i, j int) bool {
+		return resources[i].ClassName < resources[j].ClassName
+	})
+
+	return resources, rootMethods
+}
+
+// methodMemberNames returns the members generated on the SDK class for a method: the method
+// itself, its WithResponse variant and its pagination methods
+func methodMemberNames(m MethodDefinition) []string {
+	names := []string{m.MethodName()}
+	if generatorOptions.WithResponse {
+		names = append(names, withResponseMethodName(m.MethodName()))
+	}
+	if m.Pagination != nil {
+		iterateName, allName := paginationMethodNames(m)
+		names = append(names, m.Name+"Page", iterateName, allName)
+	}
+	return names
+}
+
+// resourceNames returns the property and class names of the sub-client of a resource, e.g.
+// products and ProductsApi. A resource named after a member of the SDK class is suffixed like its
+// class, e.g. contextApi, and resources whose names normalize to taken names are numbered,
+// e.g. orderItems2 and OrderItems2Api for order_items next to Order Items.
+func resourceNames(resource string, sdkMembers []string, takenProperties, takenClasses map[string]bool) (string, string) {
+	identifier := identifierFromName(resource)
+	baseProperty, baseClass := toCamelCase(identifier), toPascalCase(identifier)
+	if contains(sdkMembers, baseProperty) {
+		baseProperty += "Api"
+	}
+
+	property, class := baseProperty, baseClass+"Api"
+	for n := 2; takenProperties[property] || takenClasses[class]; n++ {
+		property, class = fmt.Sprintf("%s%d", baseProperty, n), fmt.Sprintf("%s%dApi", baseClass, n)
+	}
+	return property, class
+}
+
+// resourceMethodName removes the words naming the resource right after the leading verb of an
+// operation name, e.g. listProducts -> list, listProductVariants -> listVariants for the Products
+// resource. The By<Param> segments of names derived from paths are kept, e.g.
+// deleteCartsByCartIdItemsByItemId -> deleteByCartIdItemsByItemId for the Carts resource.
+func resourceMethodName(methodName, resource string) string {
+	resourceWords := make(map[string]bool)
+	for _, word := range strings.Split(toSnakeCase(identifierFromName(resource)), "_") {
+		if word == "" {
+			continue
+		}
+		resourceWords[word] = true
+		resourceWords[singularize(word)] = true
+	}
+
+	words := splitCamelCase(methodName)
+	if len(words) < 2 {
+		return methodName
+	}
+	rest := words[1:]
+	for len(rest) > 0 && rest[0] != "By" && resourceWords[strings.ToLower(rest[0])] {
+		rest = rest[1:]
+	}
+	return words[0] + strings.Join(rest, "")
+}
+
+// splitCamelCase splits a camelCase identifier into its words, e.g. listProductVariants -> list, Product, Variants
+func splitCamelCase(input string) []string {
+	var words []string
+	var current []rune
+	for _, r := range input {
+		if unicode.IsUpper(r) && len(current) > 0 {
+			words = append(words, string(current))
+			current = nil
+		}
+		current = append(current, r)
+	}
+	if len(current) > 0 {
+		words = append(words, string(current))
+	}
+	return words
+}
+
+// singularize returns a naive singular form of an English word, e.g. categories -> category
+func singularize(word string) string {
+	switch {
+	case strings.HasSuffix(word, "ies") && len(word) > 3:
+		return strings.TrimSuffix(word, "ies") + "y"
+	case strings.HasSuffix(word, "sses"), strings.HasSuffix(word, "xes"), strings.HasSuffix(word, "ches"), strings.HasSuffix(word, "shes"):
+		return strings.TrimSuffix(word, "es")
+	case strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss"):
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
+
+// identifierFromName converts a free-form name (e.g. a tag like "Order Items") to snake_case
+func identifierFromName(name string) string {
+	cleanName := strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, strings.TrimSpace(name))
+
+	// Split camelCase words and drop empty segments
+	var parts []string
+	for _, part := range strings.Split(toSnakeCase(cleanName), "_") {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "_")
+}
+
+// generateResourceBaseClass generates the base class of the resource sub-clients. It delegates
+// the members used by generated methods to the client of the SDK, so all sub-clients share its
+// base URL, context, credentials, server variables, request defaults and interceptors.
+func generateResourceBaseClass(members []MemberDefinition) string {
+	var buf bytes.Buffer
+	buf.WriteString("/**\n")
+	buf.WriteString(" * Base class of the resource sub-clients, sharing the request pipeline of the SDK\n")
+	buf.WriteString(" */\n")
+	buf.WriteString("abstract class ApiResource {\n")
+	buf.WriteString(fmt.Sprintf("  protected readonly client: %s;\n\n", clientInterfaceName()))
+	buf.WriteString(fmt.Sprintf("  constructor(client: %s) {\n", clientInterfaceName()))
+	buf.WriteString("    this.client = client;\n")
+	buf.WriteString("  }\n")
+	for _, member := range members {
+		if !member.Shared {
+			continue
+		}
+		buf.WriteString("\n")
+		if member.Method {
+			buf.WriteString(fmt.Sprintf("  protected %s(%s): %s {\n", member.Name, member.Params, member.Type))
+			buf.WriteString(fmt.Sprintf("    return this.client.%s(%s);\n", member.Name, paramNames(member.Params)))
+		} else {
+			buf.WriteString(fmt.Sprintf("  protected get %s(): %s {\n", member.Name, member.Type))
+			buf.WriteString(fmt.Sprintf("    return this.client.%s;\n", member.Name))
+		}
+		buf.WriteString("  }\n")
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
+
+// generateResourceClass generates the sub-client class of a resource
+func generateResourceClass(doc *openapi3.T, resource ResourceDefinition) string {
+	var buf bytes.Buffer
+	buf.WriteString("/**\n")
+	buf.WriteString(fmt.Sprintf(" * %s operations\n", resource.Name))
+	buf.WriteString(" */\n")
+	buf.WriteString(fmt.Sprintf("export class %s extends ApiResource {\n", resource.ClassName))
+	for _, m := range resource.Methods {
+		buf.WriteString(generateMethod(doc, m))
+		buf.WriteString("\n")
+	}
+	buf.WriteString("}\n")
+	return buf.String()
+}
@@ -0,0 +1,347 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ResponseHeaderDefinition represents a header declared by the success responses of an operation
+type ResponseHeaderDefinition struct {
+	Name        string // Header name, e.g. X-RateLimit-Remaining
+	Property    string // Property of the typed headers, e.g. xRateLimitRemaining
+	Type        string // string, number or boolean
+	Description string
+}
+
+// ResponseResourceDefinition locates the resource returned by an operation: its response, or
+// the data property of its response (e.g. the items of a list)
+type ResponseResourceDefinition struct {
+	Schema   *openapi3.SchemaRef
+	Type     string // TypeScript type of the resource, empty when it has none (e.g. inline resources in a data property)
+	Property string // Property of the response holding the resource, empty when the response is the resource
+	Array    bool   // The property lists resources
+	Optional bool   // The property is optional
+}
+
+// getResponseResource returns the resource returned by an operation, or nil when its success
+// response is not a JSON object
+func getResponseResource(operation *openapi3.Operation) *ResponseResourceDefinition {
+	if operation.Responses == nil {
+		return nil
+	}
+	responseType, contentType, responseRef := determineResponseType(operation)
+	if contentType != "application/json" || responseRef == nil || responseRef.Value == nil || !(isObject(responseRef.Value) || len(responseRef.Value.AllOf) > 0) {
+		return nil
+	}
+
+	data, ok := responseRef.Value.Properties["data"]
+	if !ok || data == nil || data.Value == nil {
+		resource := &ResponseResourceDefinition{Schema: responseRef}
+		if !isPrimitiveType(responseType) {
+			resource.Type = responseType
+		}
+		return resource
+	}
+
+	resource := &ResponseResourceDefinition{
+		Property: "data",
+		Optional: !contains(responseRef.Value.Required, "data"),
+	}
+	if data.Value.Type.Is("array") && data.Value.Items != nil {
+		data = data.Value.Items
+		resource.Array = true
+	}
+	if data.Value == nil {
+		return nil
+	}
+	resource.Schema = data
+	if data.Ref != "" {
+		resource.Type = toPascalCase(getRefName(data.Ref))
+	}
+	return resource
+}
+
+// responseTypeParameters returns the type parameters narrowing the response of a method: the
+// fields F of its resource and the relationships I it includes
+func responseTypeParameters(methodDefinition MethodDefinition) []string {
+	var parameters []string
+	if methodDefinition.SparseFields != nil {
+		parameters = append(parameters, methodDefinition.SparseFields.typeParameter())
+	}
+	if methodDefinition.Includes != nil {
+		parameters = append(parameters, methodDefinition.Includes.typeParameter())
+	}
+	return parameters
+}
+
+// narrowedParamsType returns the type of the params of a method inferring the type parameters
+// narrowing its response, e.g. GetProductParams<F> & { include?: I[] }
+func narrowedParamsType(methodDefinition MethodDefinition, paramsType string) string {
+	if methodDefinition.SparseFields != nil {
+		paramsType += "<F>"
+	}
+	if methodDefinition.Includes != nil {
+		paramsType += " & { include?: I[] }"
+	}
+	return paramsType
+}
+
+// narrowedResponseType returns the response type of a method narrowed to the requested fields F
+// and included relationships I, e.g. Included<Pick<Product, F>, I>, wrapped in the data property
+// of the response if the resource is there
+func narrowedResponseType(methodDefinition MethodDefinition) string {
+	resource := methodDefinition.ResponseResource
+	tsType := resource.Type
+	if methodDefinition.SparseFields != nil {
+		tsType = fmt.Sprintf("Pick<%s, F>", tsType)
+	}
+	if methodDefinition.Includes != nil {
+		tsType = fmt.Sprintf("Included<%s, I>", tsType)
+	}
+	if resource.Property == "" {
+		return tsType
+	}
+
+	if resource.Array {
+		tsType += "[]"
+	}
+	optional := ":"
+	if resource.Optional {
+		optional = "?:"
+	}
+	return fmt.Sprintf("Omit<%s, '%s'> & { %s%s %s }", methodDefinition.ResponseType, resource.Property, resource.Property, optional, tsType)
+}
+
+// getResponseHeaders returns the headers declared by the 2xx responses of an operation, sorted
+// by name. A header declared by several responses is typed from the first declaration.
+func getResponseHeaders(operation *openapi3.Operation) []ResponseHeaderDefinition {
+	if operation.Responses == nil {
+		return nil
+	}
+
+	var statuses []string
+	for status := range operation.Responses.Map() {
+		if strings.HasPrefix(status, "2") {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Strings(statuses)
+
+	seen := make(map[string]bool)
+	var headers []ResponseHeaderDefinition
+	for _, status := range statuses {
+		respRef := operation.Responses.Value(status)
+		if respRef == nil || respRef.Value == nil {
+			continue
+		}
+		for name, headerRef := range respRef.Value.Headers {
+			key := strings.ToLower(name)
+			if seen[key] || headerRef == nil || headerRef.Value == nil {
+				continue
+			}
+			seen[key] = true
+
+			header := ResponseHeaderDefinition{
+				Name:        name,
+				Property:    headerPropertyName(name),
+				Type:        "string",
+				Description: headerRef.Value.Description,
+			}
+			if schema := headerRef.Value.Schema; schema != nil && schema.Value != nil {
+				switch {
+				case schema.Value.Type.Is("integer"), schema.Value.Type.Is("number"):
+					header.Type = "number"
+				case schema.Value.Type.Is("boolean"):
+					header.Type = "boolean"
+				}
+			}
+			headers = append(headers, header)
+		}
+	}
+
+	sort.Slice(headers, func(i, j int) bool {
+		return headers[i].Property < headers[j].Property
+	})
+
+	return headers
+}
+
+// headerPropertyName converts a header name to a camelCase property name,
+// e.g. X-RateLimit-Remaining -> xRateLimitRemaining, ETag -> etag
+func headerPropertyName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	for i, part := range parts {
+		if i == 0 {
+			parts[i] = strings.ToLower(part)
+		} else {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// responseHeadersTypeName creates the name of the typed headers of an operation, e.g. GetProductResponseHeaders
+func responseHeadersTypeName(methodName string) string {
+	return toPascalCase(methodName) + "ResponseHeaders"
+}
+
+// withResponseMethodName creates the name of the method resolving to the response envelope, e.g. getProductWithResponse
+func withResponseMethodName(methodName string) string {
+	return methodName + "WithResponse"
+}
+
+// responseHeaderExpression returns the expression reading a header of the response as its type
+func responseHeaderExpression(header ResponseHeaderDefinition) string {
+	return fmt.Sprintf("%sHeader(response.headers, %s)", header.Type, quoteLiteral(header.Name))
+}
+
+// generateResponseHeaderTypes generates the typed headers of the operations declaring response headers
+func generateResponseHeaderTypes(methodDefinitions MethodDefinitions) string {
+	var buf bytes.Buffer
+	for _, m := range methodDefinitions {
+		if len(m.ResponseHeaders) == 0 {
+			continue
+		}
+		buf.WriteString(renderJSDoc([]string{fmt.Sprintf("Headers of the %s response", m.Name)}, ""))
+		buf.WriteString(fmt.Sprintf("export interface %s {\n", responseHeadersTypeName(m.Name)))
+		for _, h := range m.ResponseHeaders {
+			var docLines []string
+			if h.Description != "" {
+				docLines = append(docLines, strings.Split(strings.TrimSpace(h.Description), "\n")...)
+			}
+			docLines = append(docLines, fmt.Sprintf("Header %s", h.Name))
+			buf.WriteString(renderJSDoc(docLines, "  "))
+			buf.WriteString(fmt.Sprintf("  %s?: %s;\n", propertyKey(h.Property), h.Type))
+		}
+		buf.WriteString("}\n\n")
+	}
+	return buf.String()
+}
+
+// totalCountHeader returns the configured header carrying the total number of items of the
+// response of a method, when the method requests it (list methods, with their totalCount
+// parameter) or its response declares it
+func totalCountHeader(methodDefinition MethodDefinition) (string, bool) {
+	name := generatorOptions.TotalCountHeader
+	if name == "" {
+		return "", false
+	}
+	if strings.HasPrefix(methodDefinition.Name, "list") {
+		return name, true
+	}
+	for _, h := range methodDefinition.ResponseHeaders {
+		if strings.EqualFold(h.Name, name) {
+			return h.Name, true
+		}
+	}
+	return "", false
+}
+
+// responseImports returns the names imported from the response runtime by the generated SDK
+func responseImports(methodDefinitions MethodDefinitions) []string {
+	imports := []string{"ApiResponse"}
+	for _, m := range methodDefinitions {
+		if _, ok := totalCountHeader(m); ok {
+			imports = append(imports, "numberHeader")
+		}
+		for _, h := range m.ResponseHeaders {
+			imports = append(imports, h.Type+"Header")
+		}
+	}
+	imports = removeDuplicates(imports)
+	sort.Strings(imports)
+	return imports
+}
+
+// generateResponseData generates the code checking the status of the response and reading its
+// parsed body into a data constant
+func generateResponseData(methodDefinition MethodDefinition) string {
+	var buf bytes.Buffer
+	buf.WriteString(generateErrorHandling())
+	switch {
+	case methodDefinition.ResponseType == "void":
+		buf.WriteString("    const data: void = undefined;\n")
+	case methodDefinition.ResponseType == "Blob":
+		buf.WriteString("    const data: Blob = await response.blob();\n")
+	case methodDefinition.ResponseContentType == "text/html":
+		buf.WriteString("    const data: string = await response.text();\n")
+	default:
+		buf.WriteString(fmt.Sprintf("    const data: %s = response.status === 204 ? ({} as any) : toClientType(await response.json());\n", methodDefinition.ResponseType))
+	}
+	return buf.String()
+}
+
+// generateWithResponseMethod generates the variant of a method resolving to the parsed body
+// along with the response status and headers
+func generateWithResponseMethod(methodDefinition MethodDefinition) string {
+	var buf bytes.Buffer
+
+	args := []string{}
+	signature := []string{}
+	for _, p := range methodDefinition.Arguments {
+		args = append(args, p.Name)
+		if p.Type.Optional {
+			signature = append(signature, fmt.Sprintf("%s: %s = {}", p.Name, p.Type.Name))
+		} else {
+			signature = append(signature, fmt.Sprintf("%s: %s", p.Name, p.Type.Name))
+		}
+	}
+	signature = append(signature, "options?: RequestOptions")
+
+	headersType := "{}"
+	if len(methodDefinition.ResponseHeaders) > 0 {
+		headersType = responseHeadersTypeName(methodDefinition.Name)
+	}
+	returnType := fmt.Sprintf("ApiResponse<%s, %s>", methodDefinition.ResponseType, headersType)
+
+	docLines := []string{fmt.Sprintf("%s, resolving to the response data along with its status and headers", methodDefinition.Name)}
+	if methodDefinition.OperationRef.Deprecated {
+		docLines = append(docLines, "@deprecated")
+	}
+	for _, p := range methodDefinition.Arguments {
+		docLines = append(docLines, fmt.Sprintf("@param %s %s", p.Name, p.Type.Name))
+	}
+	docLines = append(docLines, "@param options Optional request configuration: abort signal, timeout, headers, query parameters, fetch implementation")
+	docLines = append(docLines, fmt.Sprintf("@returns Promise<%s>", returnType))
+	if len(methodDefinition.ErrorResponses) > 0 {
+		docLines = append(docLines, fmt.Sprintf("@throws {%s}", errorUnionName(methodDefinition.Name)))
+	}
+	buf.WriteString(renderJSDoc(docLines, "  "))
+
+	methodName := methodDefinition.MethodName()
+	buf.WriteString(fmt.Sprintf("  public async %s(%s): Promise<%s> {\n", withResponseMethodName(methodName), strings.Join(signature, ", "), returnType))
+
+	// The timeout covers reading the body, so it is not handed to the raw request
+	var body bytes.Buffer
+	rawArgs := append(args, "{ ...callOptions, signal: timeout.signal, timeoutMs: undefined, raw: true }")
+	body.WriteString(fmt.Sprintf("    const response = await this.%s(%s);\n", methodName, strings.Join(rawArgs, ", ")))
+	body.WriteString(generateResponseData(methodDefinition))
+	body.WriteString("    return {\n")
+	body.WriteString("      data,\n")
+	body.WriteString("      status: response.status,\n")
+	if len(methodDefinition.ResponseHeaders) > 0 {
+		body.WriteString("      headers: {\n")
+		for _, h := range methodDefinition.ResponseHeaders {
+			body.WriteString(fmt.Sprintf("        %s: %s,\n", propertyKey(h.Property), responseHeaderExpression(h)))
+		}
+		body.WriteString("      },\n")
+	} else {
+		body.WriteString("      headers: {},\n")
+	}
+	body.WriteString("      rawHeaders: response.headers,\n")
+	if header, ok := totalCountHeader(methodDefinition); ok {
+		body.WriteString(fmt.Sprintf("      totalCount: numberHeader(response.headers, %s),\n", quoteLiteral(header)))
+	}
+	body.WriteString("    };\n")
+	buf.WriteString(wrapRequestBody(body.String()))
+	buf.WriteString("  }\n")
+
+	return buf.String()
+}
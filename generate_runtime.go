@@ -0,0 +1,45 @@
+package main
+
+import (
+	"embed"
+	"io/fs"
+	"path"
+	"sort"
+)
+
+// runtimeFS holds the TypeScript support modules imported by the generated sdk.ts
+//
+//go:embed runtime/*.ts
+var runtimeFS embed.FS
+
+// RuntimeFile represents a support module written next to the generated SDK
+type RuntimeFile struct {
+	Name    string
+	Content []byte
+}
+
+// getRuntimeFiles returns the embedded runtime modules sorted by file name
+func getRuntimeFiles() ([]RuntimeFile, error) {
+	entries, err := fs.ReadDir(runtimeFS, "runtime")
+	if err != nil {
+		return nil, err
+	}
+
+	var files []RuntimeFile
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		content, err := runtimeFS.ReadFile(path.Join("runtime", entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, RuntimeFile{Name: entry.Name(), Content: content})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].Name < files[j].Name
+	})
+
+	return files, nil
+}
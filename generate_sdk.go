@@ -19,6 +19,13 @@ type QueryParameter struct {
 	Schema      *openapi3.SchemaRef
 	Required    bool
 	SDKType     string // Stores the x-gocart-sdk-type extension value
+
+	// Serialization of the parameter, with the OpenAPI defaults of its location
+	Style         string
+	Explode       bool
+	AllowReserved bool
+
+	Resource string // Component schema of the resource of a fields[type] parameter, when its fields are known
 }
 
 type MethodDefinition struct {
@@ -31,6 +38,31 @@ type MethodDefinition struct {
 	QueryParams         map[string][]QueryParameter
 	OperationRef        *openapi3.Operation
 	ResponseTypeRef     *openapi3.SchemaRef
+	ErrorResponses      []ErrorResponseDefinition
+	Resource            string // Resource sub-client the method belongs to, when grouping by tag
+	ClientName          string // Method name on the resource sub-client, when grouping by tag
+	Pagination          *PaginationDefinition
+	Security            [][]string       // Security requirements applied by the auth runtime, nil when the API declares no security schemes
+	Server              *openapi3.Server // Server overriding the document servers for the operation, if any
+	ResponseHeaders     []ResponseHeaderDefinition
+	ResponseResource    *ResponseResourceDefinition
+	SparseFields        *SparseFieldsDefinition // Narrowing of the response by the fields parameter of its resource, if any
+	Includes            *IncludesDefinition     // Narrowing of the response by the relationships it includes, if any
+}
+
+// MethodName returns the name of the generated TypeScript method
+func (m MethodDefinition) MethodName() string {
+	if m.ClientName != "" {
+		return m.ClientName
+	}
+	return m.Name
+}
+
+// ErrorResponseDefinition represents a non-2xx response declared by an operation
+type ErrorResponseDefinition struct {
+	Status    string // Status code, status range (e.g. "4XX") or "default"
+	Type      string
+	SchemaRef *openapi3.SchemaRef
 }
 
 type MethodDefinitions []MethodDefinition
@@ -64,11 +96,45 @@ func (m MethodDefinitions) UseType(typeName string) bool {
 		if p.ResponseType == typeName {
 			return true
 		}
+
+		for _, e := range p.ErrorResponses {
+			if e.Type == typeName {
+				return true
+			}
+		}
+
+		if p.Pagination != nil && p.Pagination.ItemType == typeName {
+			return true
+		}
+
+		if (p.SparseFields != nil || p.Includes != nil) && p.ResponseResource.Type == typeName {
+			return true
+		}
 	}
 
 	return false
 }
 
+// HasPagination reports whether any method is paginated
+func (m MethodDefinitions) HasPagination() bool {
+	for _, p := range m {
+		if p.Pagination != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// HasIncludes reports whether any method narrows its response by the relationships it includes
+func (m MethodDefinitions) HasIncludes() bool {
+	for _, p := range m {
+		if p.Includes != nil {
+			return true
+		}
+	}
+	return false
+}
+
 func (m MethodDefinitions) Sort() {
 	sort.Slice(m, func(i, j int) bool {
 		return m[i].Name < m[j].Name
@@ -76,8 +142,12 @@ func (m MethodDefinitions) Sort() {
 }
 
 type MethodArgumentDefinition struct {
-	Name string
-	Type TypeDefinition
+	Name      string
+	Type      TypeDefinition
+	PathParam string // Original name of the path parameter this argument is bound to, if any
+
+	// PathParameter is the declaration of the path parameter, nil when the spec does not declare it
+	PathParameter *openapi3.Parameter
 }
 
 type MethodArgumentDefinitions []MethodArgumentDefinition
@@ -91,6 +161,15 @@ func (m MethodArgumentDefinitions) HasParam(name string) bool {
 	return false
 }
 
+func (m MethodArgumentDefinitions) HasPathParam(name string) bool {
+	for _, p := range m {
+		if p.PathParam == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (m MethodArgumentDefinitions) GetPayloadParam() (MethodArgumentDefinition, bool) {
 	for _, p := range m {
 		if p.Name == "req" {
@@ -113,6 +192,7 @@ var typeMapping = map[string]string{
 
 func getMethodDefinitions(doc *openapi3.T) MethodDefinitions {
 	var methodDefinitions MethodDefinitions
+	hasSecuritySchemes := len(getSecuritySchemes(doc)) > 0
 
 	// Iterate over all paths
 	for _, path := range doc.Paths.InMatchingOrder() {
@@ -163,68 +243,50 @@ func getMethodDefinitions(doc *openapi3.T) MethodDefinitions {
 				}
 			}
 
-			// Extract parameters
+			// Extract parameters, header and cookie ones including those declared on the path
 			queryParams := extractParameters(operation)
+			inputParams := extractInputParameters(pathItem, operation)
+			queryParams[openapi3.ParameterInQuery] = withSparseFields(doc, queryParams[openapi3.ParameterInQuery])
+			delete(queryParams, openapi3.ParameterInHeader)
+			delete(queryParams, openapi3.ParameterInCookie)
+			for _, p := range inputParams {
+				queryParams[p.In] = append(queryParams[p.In], p)
+			}
 
-			var methodArgumentList MethodArgumentDefinitions
+			// Path parameters come first, followed by the request body and the query parameters
+			methodArgumentList := getPathArguments(doc, path, pathItem, operation)
 
-			// Determine parameter type and name based on HTTP method
-			switch strings.ToUpper(method) {
-			case "POST":
+			if requestType != "" {
 				methodArgumentList = append(methodArgumentList, MethodArgumentDefinition{
 					Name: "req",
 					Type: TypeDefinition{
 						Name: requestType,
 					},
 				})
-			case "PATCH", "PUT":
-				// Assume PATCH methods have an 'id' parameter and a request body
-				methodArgumentList = append(methodArgumentList, MethodArgumentDefinition{
-					Name: "id",
-					Type: TypeDefinition{
-						Name: "string",
-					},
-				}, MethodArgumentDefinition{
-					Name: "req",
-					Type: TypeDefinition{
-						Name: requestType,
-					},
-				})
+			}
 
-			case "DELETE":
-				// Assume DELETE methods only have a single string parameter
-				methodArgumentList = append(methodArgumentList, MethodArgumentDefinition{
-					Name: "id",
-					Type: TypeDefinition{
-						Name: "string",
-					},
-				})
-			case "GET":
-				// check if it is getOne or getAll
-				if !strings.Contains(methodName, "list") {
-					methodArgumentList = append(methodArgumentList, MethodArgumentDefinition{
-						Name: "id",
-						Type: TypeDefinition{
-							Name: "string",
-						},
-					})
-				}
+			// Operations other than GET take params when they declare query, header or cookie parameters
+			if strings.ToUpper(method) == "GET" || len(queryParams["query"]) > 0 || len(inputParams) > 0 {
 				paramTypeName := toPascalCase(methodName) + "Params"
 				methodArgumentList = append(methodArgumentList, MethodArgumentDefinition{
 					Name: "params",
 					Type: TypeDefinition{
 						Name:     paramTypeName,
-						Optional: true,
+						Optional: !paramsRequired(queryParams["query"]) && !paramsRequired(inputParams),
 					},
 				})
-			default:
-				fmt.Println("unsupported method:", method)
 			}
 
 			// Determine response type
 			responseType, responseContentType, ResponseTypeRef := determineResponseType(operation)
+			errorResponses := determineErrorResponses(operation, methodName)
+
+			resource := ""
+			if generatorOptions.GroupByTag {
+				resource = getOperationResource(pathItem, operation)
+			}
 
-			methodDefinitions = append(methodDefinitions, MethodDefinition{
+			methodDefinition := MethodDefinition{
 				Name:                methodName,
 				HTTPMethod:          method,
 				Path:                path,
@@ -234,7 +296,22 @@ func getMethodDefinitions(doc *openapi3.T) MethodDefinitions {
 				QueryParams:         queryParams,
 				OperationRef:        operation,
 				ResponseTypeRef:     ResponseTypeRef,
-			})
+				ErrorResponses:      errorResponses,
+				Resource:            resource,
+				Server:              getOperationServer(pathItem, operation),
+				ResponseHeaders:     getResponseHeaders(operation),
+				ResponseResource:    getResponseResource(operation),
+				SparseFields:        getSparseFieldsDefinition(doc, operation, queryParams[openapi3.ParameterInQuery]),
+				Includes:            getIncludesDefinition(doc, operation, queryParams[openapi3.ParameterInQuery], generateInterfaceName(methodName)),
+			}
+			if hasSecuritySchemes {
+				methodDefinition.Security = getSecurityRequirements(doc, operation)
+			}
+			if generatorOptions.Pagination {
+				methodDefinition.Pagination = getPaginationDefinition(methodDefinition)
+			}
+
+			methodDefinitions = append(methodDefinitions, methodDefinition)
 		}
 	}
 
@@ -243,8 +320,78 @@ func getMethodDefinitions(doc *openapi3.T) MethodDefinitions {
 	return methodDefinitions
 }
 
+// reservedArgumentNames are identifiers used by generated method bodies, which path
+// parameters must not shadow
+var reservedArgumentNames = map[string]struct{}{
+	"req":             {},
+	"params":          {},
+	"options":         {},
+	"url":             {},
+	"finalUrl":        {},
+	"body":            {},
+	"formData":        {},
+	"requestOptions":  {},
+	"queryString":     {},
+	"response":        {},
+	"data":            {},
+	"embeddedObjects": {},
+	"pageUrl":         {},
+	"cursor":          {},
+	"callOptions":     {},
+	"timeout":         {},
+	"paramHeaders":    {},
+	"cookies":         {},
+}
+
+// getPathArguments builds method arguments from the path parameters of an operation,
+// ordered as they appear in the path and typed from their schemas
+func getPathArguments(doc *openapi3.T, path string, pathItem *openapi3.PathItem, operation *openapi3.Operation) MethodArgumentDefinitions {
+	// Operation-level parameters override path-level ones with the same name
+	declared := make(map[string]*openapi3.Parameter)
+	for _, params := range []openapi3.Parameters{pathItem.Parameters, operation.Parameters} {
+		for _, paramRef := range params {
+			if paramRef == nil || paramRef.Value == nil || paramRef.Value.In != openapi3.ParameterInPath {
+				continue
+			}
+			declared[paramRef.Value.Name] = paramRef.Value
+		}
+	}
+
+	var args MethodArgumentDefinitions
+	for _, name := range extractPathParams(path) {
+		if args.HasPathParam(name) {
+			continue
+		}
+
+		// Path parameters missing from the spec are still bound, as plain strings
+		tsType := "string"
+		param := declared[name]
+		if param != nil && param.Schema != nil {
+			tsType, _ = resolveType(param.Schema, doc)
+		}
+
+		argName := toCamelCase(name)
+		if _, reserved := reservedArgumentNames[argName]; reserved || args.HasParam(argName) {
+			argName += "Param"
+		}
+
+		args = append(args, MethodArgumentDefinition{
+			Name: argName,
+			Type: TypeDefinition{
+				Name: tsType,
+			},
+			PathParam:     name,
+			PathParameter: param,
+		})
+	}
+
+	return args
+}
+
 func generateSDK(doc *openapi3.T, typeDefinitions []TypeDefinition, paramDefinitions []ParamDefinition) []byte {
 	methodDefinitions := getMethodDefinitions(doc)
+	securitySchemes := getSecuritySchemes(doc)
+	serverVariables := getServerVariables(doc, methodDefinitions)
 
 	// Generate import statements with collected types
 	importTypes := []string{}
@@ -261,6 +408,15 @@ func generateSDK(doc *openapi3.T, typeDefinitions []TypeDefinition, paramDefinit
 			importParams = append(importParams, m.Name)
 		}
 	}
+	for _, m := range methodDefinitions {
+		if m.SparseFields != nil && !contains(importParams, m.SparseFields.FieldType) {
+			importParams = append(importParams, m.SparseFields.FieldType)
+		}
+		if m.Includes != nil {
+			importParams = append(importParams, m.Includes.OptionType)
+		}
+	}
+	importParams = append(importParams, fieldNamesImports(methodDefinitions)...)
 	sort.Strings(importParams)
 
 	// Prepare to collect all TypeScript methods and types
@@ -274,121 +430,158 @@ func generateSDK(doc *openapi3.T, typeDefinitions []TypeDefinition, paramDefinit
 		for _, tsType := range importTypes {
 			tsBuffer.WriteString(fmt.Sprintf("  %s,\n", tsType))
 		}
-		tsBuffer.WriteString("  APIError,\n")
-		tsBuffer.WriteString("} from './types';\n")
+		tsBuffer.WriteString("} from './types';\n\n")
 	}
 
-	// Generate import statement for params.ts
-	if len(importParams) > 0 {
-		if len(importTypes) > 0 {
-			tsBuffer.WriteString("\n")
-		}
-		tsBuffer.WriteString("import {\n")
-		for _, tsType := range importParams {
-			tsBuffer.WriteString(fmt.Sprintf("  %s,\n", tsType))
-		}
-		tsBuffer.WriteString("  RetryRequest,\n")
-		tsBuffer.WriteString("} from './params';\n\n")
+	// Generate import statement for params.ts (RetryRequest is always needed by executeRequest)
+	tsBuffer.WriteString("import {\n")
+	for _, tsType := range importParams {
+		tsBuffer.WriteString(fmt.Sprintf("  %s,\n", tsType))
 	}
+	tsBuffer.WriteString("  RetryRequest,\n")
+	tsBuffer.WriteString("} from './params';\n\n")
 
 	tsBuffer.WriteString("import { InMemoryContext } from './context';\n")
-	tsBuffer.WriteString("import { ApiError } from './error';\n")
+	tsBuffer.WriteString(fmt.Sprintf("import { %s } from './error';\n", strings.Join(errorImports(methodDefinitions), ", ")))
 	tsBuffer.WriteString("import { toApiType, toClientType } from './utils';\n")
-	tsBuffer.WriteString("import { RequestInterceptor, ResponseInterceptor, InterceptorManager } from './interceptors';\n\n")
+	tsBuffer.WriteString("import { RequestInterceptor, ResponseInterceptor, InterceptorManager } from './interceptors';\n")
+	tsBuffer.WriteString("import { MAX_INTERCEPTOR_RETRIES, RetryPolicy, resolveRetryPolicy, retryDelay, shouldRetry, sleep } from './retry';\n")
+	tsBuffer.WriteString("import { DefaultRequestOptions, RawRequestOptions, RequestOptions, applyQuery, mergeRequestOptions, withTimeout } from './request';\n")
+	if generatorOptions.WithResponse {
+		tsBuffer.WriteString(fmt.Sprintf("import { %s } from './response';\n", strings.Join(responseImports(methodDefinitions), ", ")))
+	}
+	if methodDefinitions.HasPagination() {
+		tsBuffer.WriteString("import { Page, PaginationOptions, collect, paginate } from './pagination';\n")
+	}
+	if len(securitySchemes) > 0 {
+		tsBuffer.WriteString(fmt.Sprintf("import { %s } from './auth';\n", strings.Join(authImports(securitySchemes), ", ")))
+	}
+	if imports := serversImports(methodDefinitions, len(serverVariables) > 0); len(imports) > 0 {
+		tsBuffer.WriteString(fmt.Sprintf("import { %s } from './servers';\n", strings.Join(imports, ", ")))
+	}
+	if methodDefinitions.HasIncludes() {
+		tsBuffer.WriteString("import { Included } from './include';\n")
+	}
+	if imports := serializeImports(methodDefinitions); len(imports) > 0 {
+		tsBuffer.WriteString(fmt.Sprintf("import { %s } from './serialize';\n", strings.Join(imports, ", ")))
+	}
+	tsBuffer.WriteString("\n")
 	tsBuffer.WriteString("const SDK_VERSION = 'unset';\n\n")
 
-	// Start GoCartSDK class
-	tsBuffer.WriteString("export class GoCartSDK {\n")
-	tsBuffer.WriteString("  private baseUrl: string;\n\n")
-	tsBuffer.WriteString("  public context: InMemoryContext;\n")
-	tsBuffer.WriteString("  public interceptors: {\n")
-	tsBuffer.WriteString("    request: InterceptorManager<RequestInterceptor>;\n")
-	tsBuffer.WriteString("    response: InterceptorManager<ResponseInterceptor>;\n")
-	tsBuffer.WriteString("  };\n\n")
-
-	tsBuffer.WriteString("  constructor(baseUrl: string = 'https://api.orbita.al') {\n")
-	tsBuffer.WriteString("    this.baseUrl = baseUrl;\n")
+	// Generate the constructor options: credentials, server variables and retry policy
+	if len(securitySchemes) > 0 {
+		tsBuffer.WriteString(generateAuthTypes(securitySchemes))
+	}
+	if len(serverVariables) > 0 {
+		tsBuffer.WriteString(generateServerTypes(serverVariables))
+	}
+	tsBuffer.WriteString(generateSDKOptions(len(securitySchemes) > 0, len(serverVariables) > 0))
+
+	// Generate error unions for operations declaring error responses
+	hasErrorUnions := false
+	for _, m := range methodDefinitions {
+		if len(m.ErrorResponses) == 0 {
+			continue
+		}
+		members := []string{}
+		for _, e := range m.ErrorResponses {
+			members = append(members, fmt.Sprintf("ApiError<%s, %s>", errorStatusType(e.Status, m.ErrorResponses), e.Type))
+		}
+		members = removeDuplicates(members)
+		tsBuffer.WriteString(fmt.Sprintf("export type %s = %s;\n", errorUnionName(m.Name), strings.Join(members, " | ")))
+		hasErrorUnions = true
+	}
+	if hasErrorUnions {
+		tsBuffer.WriteString("\n")
+	}
+
+	// Generate the typed headers of operations declaring response headers
+	if generatorOptions.WithResponse {
+		tsBuffer.WriteString(generateResponseHeaderTypes(methodDefinitions))
+	}
+
+	fields := sdkFields(len(securitySchemes) > 0, len(serverVariables) > 0)
+	methods := sdkMethods(len(securitySchemes) > 0)
+	members := append(append([]MemberDefinition{}, fields...), methods...)
+
+	// Group operations into resource sub-clients if enabled
+	var resources []ResourceDefinition
+	rootMethods := methodDefinitions
+	if generatorOptions.GroupByTag {
+		resources, rootMethods = groupMethodsByResource(methodDefinitions, memberNames(members))
+	}
+
+	// Start SDK class
+	tsBuffer.WriteString(fmt.Sprintf("export class %s {\n", generatorOptions.ClassName))
+	for _, field := range fields {
+		tsBuffer.WriteString(field.Code)
+	}
+
+	for _, resource := range resources {
+		tsBuffer.WriteString(fmt.Sprintf("  public readonly %s: %s;\n", resource.PropertyName, resource.ClassName))
+	}
+	if len(resources) > 0 {
+		tsBuffer.WriteString("\n")
+	}
+
+	// The default base URL is the first server of the document
+	defaultServer := getDefaultServer(doc)
+	defaultServerURL := quoteLiteral(generatorOptions.DefaultBaseURL)
+	if defaultServer != nil {
+		defaultServerURL = serverURLExpression(defaultServer)
+	}
+	switch {
+	case len(serverVariables) > 0:
+		tsBuffer.WriteString(fmt.Sprintf("  constructor(baseUrl?: string, options: %s = {}) {\n", sdkTypeName("Options")))
+		tsBuffer.WriteString("    this.serverVariables = { ...options.serverVariables };\n")
+		tsBuffer.WriteString(fmt.Sprintf("    this.baseUrl = baseUrl ?? %s;\n", defaultServerURL))
+	default:
+		tsBuffer.WriteString(fmt.Sprintf("  constructor(baseUrl: string = %s, options: %s = {}) {\n", defaultServerURL, sdkTypeName("Options")))
+		tsBuffer.WriteString("    this.baseUrl = baseUrl;\n")
+	}
 	tsBuffer.WriteString("    this.context = new InMemoryContext();\n")
 	tsBuffer.WriteString("    this.interceptors = {\n")
 	tsBuffer.WriteString("      request: new InterceptorManager<RequestInterceptor>(),\n")
 	tsBuffer.WriteString("      response: new InterceptorManager<ResponseInterceptor>()\n")
 	tsBuffer.WriteString("    };\n")
+	tsBuffer.WriteString("    this.retryPolicy = resolveRetryPolicy(options.retry);\n")
+	tsBuffer.WriteString("    this.defaults = options.defaults ?? {};\n")
+	if len(securitySchemes) > 0 {
+		tsBuffer.WriteString("    this.auth = new AuthManager(SECURITY_SCHEMES, options.auth);\n")
+	}
+	if len(resources) > 0 {
+		tsBuffer.WriteString(generateClientObject(members))
+	}
+	for _, resource := range resources {
+		tsBuffer.WriteString(fmt.Sprintf("    this.%s = new %s(client);\n", resource.PropertyName, resource.ClassName))
+	}
 	tsBuffer.WriteString("  }\n\n")
 
-	// Add formatFilterValue helper method
-	tsBuffer.WriteString("  /**\n")
-	tsBuffer.WriteString("   * Format filter values, converting camelCase strings to snake_case\n")
-	tsBuffer.WriteString("   * @private\n")
-	tsBuffer.WriteString("   */\n")
-	tsBuffer.WriteString("  private formatFilterValue(value: any): string {\n")
-	tsBuffer.WriteString("    let formattedValue = String(value);\n")
-	tsBuffer.WriteString("    // Convert camelCase string values to snake_case\n")
-	tsBuffer.WriteString("    if (typeof value === 'string' && formattedValue !== formattedValue.toLowerCase()) {\n")
-	tsBuffer.WriteString("      formattedValue = formattedValue.replace(/([a-z])([A-Z])/g, '$1_$2').toLowerCase();\n")
-	tsBuffer.WriteString("    }\n")
-	tsBuffer.WriteString("    return formattedValue;\n")
-	tsBuffer.WriteString("  }\n")
-	tsBuffer.WriteString("\n")
-
-	// Add executeRequest method
-	tsBuffer.WriteString("  /**\n")
-	tsBuffer.WriteString("   * Execute a request with interceptor support and retry capability\n")
-	tsBuffer.WriteString("   * @private\n")
-	tsBuffer.WriteString("   */\n")
-	tsBuffer.WriteString("  private async executeRequest(url: string, options: RequestInit): Promise<Response> {\n")
-	tsBuffer.WriteString("    let finalUrl = url;\n")
-	tsBuffer.WriteString("    let currentOptions = { ...options };\n")
-	tsBuffer.WriteString("\n")
-	tsBuffer.WriteString("    // Apply request interceptors\n")
-	tsBuffer.WriteString("    for (const interceptor of this.interceptors.request.interceptors) {\n")
-	tsBuffer.WriteString("      const result = await interceptor(currentOptions, finalUrl);\n")
-	tsBuffer.WriteString("      if (result) {\n")
-	tsBuffer.WriteString("        if (result.options) currentOptions = result.options;\n")
-	tsBuffer.WriteString("        if (result.url) finalUrl = result.url;\n")
-	tsBuffer.WriteString("      }\n")
-	tsBuffer.WriteString("    }\n")
-	tsBuffer.WriteString("\n")
-	tsBuffer.WriteString("    // Make the request\n")
-	tsBuffer.WriteString("    let response = await fetch(finalUrl, currentOptions);\n")
-	tsBuffer.WriteString("\n")
-	tsBuffer.WriteString("    // Apply response interceptors\n")
-	tsBuffer.WriteString("    for (const interceptor of this.interceptors.response.interceptors) {\n")
-	tsBuffer.WriteString("      const result = await interceptor(response, currentOptions, finalUrl);\n")
-	tsBuffer.WriteString("      if (result) {\n")
-	tsBuffer.WriteString("        // Check if the interceptor returned a retry request\n")
-	tsBuffer.WriteString("        if (this.isRetryRequest(result)) {\n")
-	tsBuffer.WriteString("          // Recursively execute the retry request\n")
-	tsBuffer.WriteString("          return this.executeRequest(result.url, result.options);\n")
-	tsBuffer.WriteString("        } else {\n")
-	tsBuffer.WriteString("          // Replace the response with the modified one\n")
-	tsBuffer.WriteString("          response = result;\n")
-	tsBuffer.WriteString("        }\n")
-	tsBuffer.WriteString("      }\n")
-	tsBuffer.WriteString("    }\n")
-	tsBuffer.WriteString("\n")
-	tsBuffer.WriteString("    return response;\n")
-	tsBuffer.WriteString("  }\n")
-	tsBuffer.WriteString("\n")
-
-	// Add isRetryRequest type guard
-	tsBuffer.WriteString("  /**\n")
-	tsBuffer.WriteString("   * Type guard to check if the result is a RetryRequest\n")
-	tsBuffer.WriteString("   * @private\n")
-	tsBuffer.WriteString("   */\n")
-	tsBuffer.WriteString("  private isRetryRequest(result: Response | RetryRequest): result is RetryRequest {\n")
-	tsBuffer.WriteString("    return (result as RetryRequest).url !== undefined && (result as RetryRequest).options !== undefined;\n")
-	tsBuffer.WriteString("  }\n")
-	tsBuffer.WriteString("\n")
+	for _, method := range methods {
+		tsBuffer.WriteString(method.Code)
+	}
 
-	for _, m := range methodDefinitions {
+	for _, m := range rootMethods {
 		mthodCode := generateMethod(doc, m)
 		tsBuffer.WriteString(mthodCode)
 		tsBuffer.WriteString("\n")
 	}
 
-	// Close GoCartSDK class
+	// Close SDK class
 	tsBuffer.WriteString("}\n")
 
+	// Generate the resource sub-clients
+	if len(resources) > 0 {
+		tsBuffer.WriteString("\n")
+		tsBuffer.WriteString(generateClientInterface(members))
+		tsBuffer.WriteString("\n")
+		tsBuffer.WriteString(generateResourceBaseClass(members))
+		for _, resource := range resources {
+			tsBuffer.WriteString("\n")
+			tsBuffer.WriteString(generateResourceClass(doc, resource))
+		}
+	}
+
 	return tsBuffer.Bytes()
 }
 
@@ -396,9 +589,37 @@ func isArrayType(tsType string) bool {
 	return strings.HasSuffix(tsType, "[]")
 }
 
-// generateMethodName creates a TypeScript method name based on operationId or HTTP method and path
+// generateMethodName creates a TypeScript method name from the x-gocart-sdk-name extension, the
+// operationId, or else the HTTP method and path
 func generateMethodName(operation *openapi3.Operation, method, path string) string {
-	return operation.OperationID
+	if name, ok := operation.Extensions["x-gocart-sdk-name"].(string); ok && name != "" {
+		return name
+	}
+	if operation.OperationID != "" {
+		return operation.OperationID
+	}
+	return fallbackMethodName(method, path)
+}
+
+// fallbackMethodName derives the method name of an operation without operationId from its HTTP
+// method and path, e.g. GET /stores/{store_id}/products gives getStoresByStoreIdProducts
+func fallbackMethodName(method, path string) string {
+	var name strings.Builder
+	name.WriteString(strings.ToLower(method))
+	for _, segment := range strings.Split(path, "/") {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			name.WriteString("By")
+		}
+		words := strings.FieldsFunc(segment, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		for _, word := range words {
+			runes := []rune(word)
+			runes[0] = unicode.ToUpper(runes[0])
+			name.WriteString(string(runes))
+		}
+	}
+	return name.String()
 }
 
 // extractParameters separates path, query, and body parameters
@@ -421,15 +642,7 @@ func extractParameters(operation *openapi3.Operation) map[string][]QueryParamete
 			}
 		}
 
-		p := QueryParameter{
-			Name:        param.Name,
-			In:          param.In,
-			Description: param.Description,
-			Schema:      param.Schema,
-			Required:    param.Required,
-			SDKType:     sdkType,
-		}
-		groupedParams[param.In] = append(groupedParams[param.In], p)
+		groupedParams[param.In] = append(groupedParams[param.In], newQueryParameter(param, sdkType))
 	}
 
 	// Handle requestBody if exists
@@ -497,6 +710,159 @@ func determineResponseType(operation *openapi3.Operation) (string, string, *open
 	return "any", "", nil
 }
 
+// determineErrorResponses collects the non-2xx and default responses declared by the operation
+func determineErrorResponses(operation *openapi3.Operation, methodName string) []ErrorResponseDefinition {
+	if operation.Responses == nil {
+		return nil
+	}
+
+	var statuses []string
+	for status := range operation.Responses.Map() {
+		if isErrorStatus(status) {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Strings(statuses)
+
+	var errorResponses []ErrorResponseDefinition
+	for _, status := range statuses {
+		errorResponse := ErrorResponseDefinition{
+			Status: status,
+			Type:   "any",
+		}
+		if !isLiteralStatus(status) {
+			// The body of a catch-all response is only known when it declares a schema
+			errorResponse.Type = "unknown"
+		}
+
+		respRef := operation.Responses.Value(status)
+		if respRef != nil && respRef.Value != nil {
+			if schema := jsonContentSchema(respRef.Value.Content); schema != nil {
+				errorResponse.SchemaRef = schema
+				if schema.Ref != "" {
+					errorResponse.Type = toPascalCase(getRefName(schema.Ref))
+				} else {
+					// Inline schemas are generated in types.ts under a per-operation name
+					errorResponse.Type = errorTypeName(methodName, status)
+				}
+			}
+		}
+
+		errorResponses = append(errorResponses, errorResponse)
+	}
+
+	return errorResponses
+}
+
+// isErrorStatus checks if a response key describes an error response (anything but 1xx/2xx)
+func isErrorStatus(status string) bool {
+	if status == "default" {
+		return true
+	}
+	return status != "" && status[0] != '1' && status[0] != '2'
+}
+
+// rangeStatusTypes are the types of the status codes of response ranges, by first digit
+var rangeStatusTypes = map[byte]string{
+	'3': "RedirectionStatus",
+	'4': "ClientErrorStatus",
+	'5': "ServerErrorStatus",
+}
+
+// errorStatusType returns the TypeScript type of the status code for a response key of an
+// operation. Ranges and default exclude the statuses of the other error responses, so that
+// comparing the status narrows the error union, e.g. "409" -> "409",
+// "4XX" -> "Exclude<ClientErrorStatus, 409>", "default" -> "Exclude<ErrorStatus, 409 | ClientErrorStatus>"
+func errorStatusType(status string, errorResponses []ErrorResponseDefinition) string {
+	if isLiteralStatus(status) {
+		return status
+	}
+
+	base := "ErrorStatus"
+	if status != "default" {
+		var ok bool
+		if base, ok = rangeStatusTypes[status[0]]; !ok || !strings.EqualFold(status[1:], "XX") {
+			return "number"
+		}
+	}
+
+	var excluded []string
+	for _, e := range errorResponses {
+		switch {
+		case e.Status == status:
+		case isLiteralStatus(e.Status) && (status == "default" || e.Status[0] == status[0]):
+			excluded = append(excluded, e.Status)
+		case status == "default" && e.Status != "default" && strings.EqualFold(e.Status[1:], "XX") && rangeStatusTypes[e.Status[0]] != "":
+			excluded = append(excluded, rangeStatusTypes[e.Status[0]])
+		}
+	}
+	if len(excluded) == 0 {
+		return base
+	}
+	return fmt.Sprintf("Exclude<%s, %s>", base, strings.Join(excluded, " | "))
+}
+
+// isLiteralStatus reports whether a response key is a status code, e.g. 409
+func isLiteralStatus(status string) bool {
+	for _, r := range status {
+		if !unicode.IsDigit(r) {
+			return false
+		}
+	}
+	return status != ""
+}
+
+// errorImports returns the names imported from the error runtime module: ApiError and the
+// status types of the error unions
+func errorImports(methodDefinitions MethodDefinitions) []string {
+	var statusTypes []string
+	for _, m := range methodDefinitions {
+		for _, e := range m.ErrorResponses {
+			statusType := errorStatusType(e.Status, m.ErrorResponses)
+			for _, name := range strings.FieldsFunc(statusType, func(r rune) bool { return !unicode.IsLetter(r) }) {
+				if strings.HasSuffix(name, "Status") && !contains(statusTypes, name) {
+					statusTypes = append(statusTypes, name)
+				}
+			}
+		}
+	}
+	sort.Strings(statusTypes)
+	return append([]string{"ApiError"}, statusTypes...)
+}
+
+// errorTypeName creates the name of an inline error response type, e.g. CreateCart409Error
+func errorTypeName(methodName, status string) string {
+	if status == "default" {
+		return toPascalCase(methodName) + "DefaultError"
+	}
+	return toPascalCase(methodName) + strings.ToUpper(status) + "Error"
+}
+
+// errorUnionName creates the name of the error union of an operation, e.g. CreateCartError
+func errorUnionName(methodName string) string {
+	return toPascalCase(methodName) + "Error"
+}
+
+// jsonContentSchema returns the schema of the JSON content (application/json or any +json type)
+func jsonContentSchema(content openapi3.Content) *openapi3.SchemaRef {
+	if appJSON, ok := content["application/json"]; ok && appJSON.Schema != nil {
+		return appJSON.Schema
+	}
+
+	contentTypes := make([]string, 0, len(content))
+	for contentType := range content {
+		contentTypes = append(contentTypes, contentType)
+	}
+	sort.Strings(contentTypes)
+
+	for _, contentType := range contentTypes {
+		if strings.HasSuffix(contentType, "+json") && content[contentType].Schema != nil {
+			return content[contentType].Schema
+		}
+	}
+	return nil
+}
+
 // isBinaryContentType checks if the content type represents binary data
 func isBinaryContentType(contentType string) bool {
 	binaryTypes := []string{
@@ -523,6 +889,20 @@ func isBinaryContentType(contentType string) bool {
 
 // resolveInlineType resolves TypeScript types from inline OpenAPI schemas
 func resolveInlineType(schema *openapi3.SchemaRef) string {
+	// Handle oneOf/anyOf unions
+	if len(schema.Value.OneOf) > 0 || len(schema.Value.AnyOf) > 0 {
+		members := schema.Value.OneOf
+		if len(members) == 0 {
+			members = schema.Value.AnyOf
+		}
+		return resolveUnion(members, schema.Value.Discriminator, func(member *openapi3.SchemaRef) string {
+			if member.Ref != "" {
+				return toPascalCase(getRefName(member.Ref))
+			}
+			return resolveInlineType(member)
+		})
+	}
+
 	if schema.Value.Type != nil {
 		if len(*schema.Value.Type) == 1 {
 			openType := strings.ToLower((*schema.Value.Type)[0])
@@ -726,146 +1106,350 @@ func generateRangeQueryBuilder(paramName, camelKey, sdkType, queryKey string) st
 	return b.String()
 }
 
-// generateMethod creates a TypeScript method within the GoCartSDK class
-func generateMethod(doc *openapi3.T, methodDefinition MethodDefinition) string {
-	var buf bytes.Buffer
+// operationDocLines returns the JSDoc lines describing an operation: its summary (or the method
+// name), description, deprecation, tags and external documentation
+func operationDocLines(methodDefinition MethodDefinition) []string {
+	operation := methodDefinition.OperationRef
 
-	// Generate JSDoc comments
-	buf.WriteString("  /**\n")
-	buf.WriteString(fmt.Sprintf("   * %s\n", methodDefinition.Name))
-	for _, p := range methodDefinition.Arguments {
-		buf.WriteString(fmt.Sprintf("   * @param %s %s\n", p.Name, p.Type.Name))
+	lines := []string{methodDefinition.Name}
+	if operation.Summary != "" {
+		lines = strings.Split(strings.TrimSpace(operation.Summary), "\n")
+	}
+	if operation.Description != "" {
+		lines = append(lines, "")
+		lines = append(lines, strings.Split(strings.TrimSpace(operation.Description), "\n")...)
 	}
-	buf.WriteString("   * @param options Optional request configuration including abort signal\n")
-	buf.WriteString(fmt.Sprintf("   * @returns Promise<%s>\n", methodDefinition.ResponseType))
-	buf.WriteString("   */\n")
 
-	// Generate method signature
+	if operation.Deprecated {
+		lines = append(lines, "@deprecated")
+	}
+	for _, tag := range operation.Tags {
+		lines = append(lines, "@category "+tag)
+	}
+	if operation.ExternalDocs != nil && operation.ExternalDocs.URL != "" {
+		lines = append(lines, externalDocsTag(operation.ExternalDocs))
+	}
+
+	return lines
+}
+
+// generateMethod creates a TypeScript method within the SDK class
+func generateMethod(doc *openapi3.T, methodDefinition MethodDefinition) string {
+	var buf bytes.Buffer
+
+	// Generate method signatures: an overload resolving to the raw Response, then the parsed body.
+	// The raw overload is followed by its required options, so its optional arguments can only be
+	// passed as undefined, while the parsed body overload can omit them.
+	overloadArgs := []string{}
+	parsedArgs := make([]string, len(methodDefinition.Arguments))
 	paramsSignature := []string{}
 	for _, p := range methodDefinition.Arguments {
 		if p.Type.Optional {
+			overloadArgs = append(overloadArgs, fmt.Sprintf("%s: %s | undefined", p.Name, p.Type.Name))
 			paramsSignature = append(paramsSignature, fmt.Sprintf("%s: %s = {}", p.Name, p.Type.Name))
 		} else {
+			overloadArgs = append(overloadArgs, fmt.Sprintf("%s: %s", p.Name, p.Type.Name))
 			paramsSignature = append(paramsSignature, fmt.Sprintf("%s: %s", p.Name, p.Type.Name))
 		}
 	}
+	omittable := true
+	for i := len(methodDefinition.Arguments) - 1; i >= 0; i-- {
+		p := methodDefinition.Arguments[i]
+		omittable = omittable && p.Type.Optional
+		if omittable {
+			parsedArgs[i] = fmt.Sprintf("%s?: %s", p.Name, p.Type.Name)
+		} else {
+			parsedArgs[i] = overloadArgs[i]
+		}
+	}
+	methodName := methodDefinition.MethodName()
+
+	buf.WriteString(renderJSDoc([]string{fmt.Sprintf("%s, resolving to the raw Response", methodDefinition.Name)}, "  "))
+	buf.WriteString(fmt.Sprintf("  public %s(%s): Promise<Response>;\n", methodName, strings.Join(append(overloadArgs, "options: RawRequestOptions"), ", ")))
+
+	// Generate JSDoc comments
+	docLines := operationDocLines(methodDefinition)
+	for _, p := range methodDefinition.Arguments {
+		docLines = append(docLines, fmt.Sprintf("@param %s %s", p.Name, p.Type.Name))
+	}
+	docLines = append(docLines, "@param options Optional request configuration: abort signal, timeout, headers, query parameters, fetch implementation")
+	docLines = append(docLines, fmt.Sprintf("@returns Promise<%s>", methodDefinition.ResponseType))
+	if len(methodDefinition.ErrorResponses) > 0 {
+		docLines = append(docLines, fmt.Sprintf("@throws {%s}", errorUnionName(methodDefinition.Name)))
+	}
+	buf.WriteString(renderJSDoc(docLines, "  "))
+	if typeParameters := responseTypeParameters(methodDefinition); len(typeParameters) > 0 {
+		// The response is narrowed to the fields and relationships requested for its resource
+		narrowedArgs := []string{}
+		for _, p := range methodDefinition.Arguments {
+			if p.Name == "params" {
+				narrowedArgs = append(narrowedArgs, strings.Replace(parsedArgs[len(narrowedArgs)], p.Type.Name, narrowedParamsType(methodDefinition, p.Type.Name), 1))
+			} else {
+				narrowedArgs = append(narrowedArgs, parsedArgs[len(narrowedArgs)])
+			}
+		}
+		buf.WriteString(fmt.Sprintf("  public %s<%s>(%s): Promise<%s>;\n", methodName, strings.Join(typeParameters, ", "), strings.Join(append(narrowedArgs, "options?: RequestOptions"), ", "), narrowedResponseType(methodDefinition)))
+	} else {
+		buf.WriteString(fmt.Sprintf("  public %s(%s): Promise<%s>;\n", methodName, strings.Join(append(parsedArgs, "options?: RequestOptions"), ", "), methodDefinition.ResponseType))
+	}
+
 	// Add optional options parameter
-	paramsSignature = append(paramsSignature, "options?: { signal?: AbortSignal }")
-	buf.WriteString(fmt.Sprintf("  public async %s(%s): Promise<%s> {\n", methodDefinition.Name, strings.Join(paramsSignature, ", "), methodDefinition.ResponseType))
+	paramsSignature = append(paramsSignature, "options?: RequestOptions")
+	buf.WriteString(fmt.Sprintf("  public async %s(%s): Promise<%s | Response> {\n", methodName, strings.Join(paramsSignature, ", "), wrapUnion(methodDefinition.ResponseType)))
+
+	// Build and execute the request
+	var body bytes.Buffer
+	body.WriteString(generateRequest(doc, methodDefinition))
+	body.WriteString("    if (callOptions.raw) {\n")
+	body.WriteString("      return response;\n")
+	body.WriteString("    }\n")
+
+	body.WriteString("\n")
+	body.WriteString("    if (response.status === 204) {\n")
+	if methodDefinition.ResponseType == "void" {
+		body.WriteString("      return;\n")
+	} else {
+		body.WriteString("      return {} as any;\n")
+	}
+	body.WriteString("    }\n\n")
+	body.WriteString(generateErrorHandling())
+	// Handle No Content responses (e.g., 204 No Content)
+	if methodDefinition.ResponseType == "void" {
+		body.WriteString("    return;\n")
+	} else if methodDefinition.ResponseType == "Blob" {
+		body.WriteString("    // Handle binary response\n")
+		body.WriteString("    const blob = await response.blob();\n")
+		body.WriteString("    return blob;\n")
+	} else if methodDefinition.ResponseContentType == "text/html" {
+		body.WriteString("    // Handle HTML response\n")
+		body.WriteString("    const html = await response.text();\n")
+		body.WriteString("    return html;\n")
+	} else {
+		body.WriteString("    const data = await response.json();\n")
+		body.WriteString("    // Transform keys to camelCase and recursively convert nested objects\n")
+		body.WriteString("    return toClientType(data);\n")
+	}
+	buf.WriteString(wrapRequestBody(body.String()))
+
+	buf.WriteString("  }\n")
+
+	// Generate the variant resolving to the response envelope
+	if generatorOptions.WithResponse {
+		buf.WriteString("\n")
+		buf.WriteString(generateWithResponseMethod(methodDefinition))
+	}
+
+	// Generate the auto-pagination methods of list operations
+	if methodDefinition.Pagination != nil {
+		buf.WriteString("\n")
+		buf.WriteString(generatePaginationMethods(methodDefinition))
+	}
+
+	return buf.String()
+}
+
+// wrapRequestBody wraps a method body in the merge of the call options with the SDK defaults
+// and the request timeout, cleared once the body completes
+func wrapRequestBody(body string) string {
+	var buf bytes.Buffer
+	buf.WriteString("    const callOptions = mergeRequestOptions(this.defaults, options);\n")
+	buf.WriteString("    const timeout = withTimeout(callOptions.signal, callOptions.timeoutMs);\n")
+	buf.WriteString("    try {\n")
+	for _, line := range strings.SplitAfter(body, "\n") {
+		if strings.TrimSpace(line) != "" {
+			buf.WriteString("  ")
+		}
+		buf.WriteString(line)
+	}
+	buf.WriteString("    } finally {\n")
+	buf.WriteString("      timeout.clear();\n")
+	buf.WriteString("    }\n")
+	return buf.String()
+}
+
+// generateInputParams generates the code collecting the header and cookie parameters of the
+// params argument into the paramHeaders object, cookies being joined into a Cookie header
+func generateInputParams(methodDefinition MethodDefinition) string {
+	var buf bytes.Buffer
+	buf.WriteString("    const paramHeaders: Record<string, string> = {};\n")
+
+	if headers := methodDefinition.QueryParams[openapi3.ParameterInHeader]; len(headers) > 0 {
+		buf.WriteString("    if (params.headers) {\n")
+		for _, p := range headers {
+			value := "params.headers" + propertyAccessor(headerPropertyName(p.Name))
+			buf.WriteString(fmt.Sprintf("      if (%s !== undefined && %s !== null) {\n", value, value))
+			buf.WriteString(fmt.Sprintf("        paramHeaders[%s] = %s;\n", quoteLiteral(p.Name), headerParamExpression(p, value)))
+			buf.WriteString("      }\n")
+		}
+		buf.WriteString("    }\n")
+	}
+
+	if cookies := methodDefinition.QueryParams[openapi3.ParameterInCookie]; len(cookies) > 0 {
+		buf.WriteString("    if (params.cookies) {\n")
+		buf.WriteString("      const cookies: string[] = [];\n")
+		for _, p := range cookies {
+			value := "params.cookies" + propertyAccessor(headerPropertyName(p.Name))
+			buf.WriteString(fmt.Sprintf("      if (%s !== undefined && %s !== null) {\n", value, value))
+			buf.WriteString(fmt.Sprintf("        cookies.push(%s);\n", cookieParamExpression(p, value)))
+			buf.WriteString("      }\n")
+		}
+		buf.WriteString("      if (cookies.length > 0) {\n")
+		buf.WriteString("        paramHeaders['Cookie'] = cookies.join('; ');\n")
+		buf.WriteString("      }\n")
+		buf.WriteString("    }\n")
+	}
+
+	return buf.String()
+}
+
+// generateErrorHandling generates the code throwing an ApiError for non-2xx responses
+func generateErrorHandling() string {
+	var buf bytes.Buffer
+	buf.WriteString("    if (!response.ok) {\n")
+	buf.WriteString("      const errMessage = await response.json().catch(() => null);\n")
+	buf.WriteString("      const err = toClientType(errMessage);\n")
+	buf.WriteString("      throw new ApiError(response.status, err);\n")
+	buf.WriteString("    }\n")
+	return buf.String()
+}
+
+// generateRequest generates the method body building the URL and request options and executing
+// the request. The pageUrl call option of paginated operations replaces the URL, to follow the
+// next page link of a list operation.
+func generateRequest(doc *openapi3.T, methodDefinition MethodDefinition) string {
+	var buf bytes.Buffer
 
 	// Construct URL with path parameters
 	url := methodDefinition.Path
-	pathParams := extractPathParams(url)
-	if len(pathParams) > 0 {
-		for _, p := range pathParams {
-			camelParam := toCamelCase(p)
-			url = strings.ReplaceAll(url, "{"+p+"}", fmt.Sprintf("${%s}", camelParam))
+	for _, p := range methodDefinition.Arguments {
+		if p.PathParam != "" {
+			url = strings.ReplaceAll(url, "{"+p.PathParam+"}", fmt.Sprintf("${%s}", pathParamExpression(p)))
 		}
-		buf.WriteString(fmt.Sprintf("    const url = `${this.baseUrl}%s`;\n", url))
-	} else {
-		buf.WriteString(fmt.Sprintf("    const url = `${this.baseUrl}%s`;\n", url))
 	}
+	baseURL := "this.baseUrl"
+	if methodDefinition.Server != nil {
+		baseURL = operationServerURLExpression(methodDefinition.Server)
+	}
+	buf.WriteString(fmt.Sprintf("    const url = `${%s}%s`;\n", baseURL, url))
 
-	if methodDefinition.HTTPMethod == "POST" || methodDefinition.HTTPMethod == "PUT" || methodDefinition.HTTPMethod == "PATCH" {
-		if methodDefinition.OperationRef.RequestBody != nil && methodDefinition.OperationRef.RequestBody.Value != nil && methodDefinition.OperationRef.RequestBody.Value.Content["application/json"] != nil {
+	// Header and cookie parameters are sent as headers, before the headers of the call options
+	hasInputParams := methodDefinition.Arguments.HasParam("params") &&
+		(len(methodDefinition.QueryParams[openapi3.ParameterInHeader]) > 0 || len(methodDefinition.QueryParams[openapi3.ParameterInCookie]) > 0)
+	if hasInputParams {
+		buf.WriteString(generateInputParams(methodDefinition))
+	}
 
-			// create an array with keys of embedded objects in the response schema
-			var embeddedObjects []string
-			requestSchema := methodDefinition.OperationRef.RequestBody.Value.Content["application/json"].Schema
+	// Only POST, PUT and PATCH requests send a body, JSON taking precedence over multipart
+	requestBody := methodDefinition.OperationRef.RequestBody
+	hasBody := (methodDefinition.HTTPMethod == "POST" || methodDefinition.HTTPMethod == "PUT" || methodDefinition.HTTPMethod == "PATCH") &&
+		requestBody != nil && requestBody.Value != nil
+	hasJSONBody := hasBody && requestBody.Value.Content["application/json"] != nil
+	hasMultipartBody := hasBody && !hasJSONBody && requestBody.Value.Content["multipart/form-data"] != nil
 
-			if requestSchema.Value.Type.Is("object") {
-				if methodDefinition.ResponseTypeRef != nil && methodDefinition.ResponseTypeRef.Value != nil {
-					schemaRef := methodDefinition.ResponseTypeRef
-					// Look for the `_embedded` property
-					embeddedObjects = getEmbeddedKeysFromSchema(schemaRef)
-				}
-			} else if requestSchema.Value.Type.Is("array") {
-				// Handle array of objects
-				if requestSchema.Value.Items != nil && requestSchema.Value.Items.Ref != "" {
-					// resolve the ref
-					itemSchema, _ := resolveSchemaRef(requestSchema.Value.Items, doc)
-					if itemSchema.Value.Type.Is("object") {
-						embeddedObjects = getEmbeddedKeysFromSchema(requestSchema.Value.Items)
-					}
-				}
-			}
+	if hasJSONBody {
 
-			// write it (sorted alphabetically)
-			sort.Strings(embeddedObjects)
-			var bufEmbedded bytes.Buffer
-			bufEmbedded.WriteString("[")
-			for i, eo := range embeddedObjects {
-				bufEmbedded.WriteString(fmt.Sprintf("'%s'", eo))
-				if i < len(embeddedObjects)-1 {
-					bufEmbedded.WriteString(", ")
-				}
+		// create an array with keys of embedded objects in the response schema
+		var embeddedObjects []string
+		requestSchema := methodDefinition.OperationRef.RequestBody.Value.Content["application/json"].Schema
+
+		if requestSchema.Value.Type.Is("object") {
+			if methodDefinition.ResponseTypeRef != nil && methodDefinition.ResponseTypeRef.Value != nil {
+				schemaRef := methodDefinition.ResponseTypeRef
+				// Look for the `_embedded` property
+				embeddedObjects = getEmbeddedKeysFromSchema(schemaRef)
 			}
-			bufEmbedded.WriteString("]")
-			buf.WriteString(fmt.Sprintf("		const embeddedObjects: string[] = %v;\n", bufEmbedded.String()))
-			if param, ok := methodDefinition.Arguments.GetPayloadParam(); ok {
-				buf.WriteString(fmt.Sprintf("		const body = toApiType(%s, embeddedObjects);\n", param.Name))
+		} else if requestSchema.Value.Type.Is("array") {
+			// Handle array of objects
+			if requestSchema.Value.Items != nil && requestSchema.Value.Items.Ref != "" {
+				// resolve the ref
+				itemSchema, _ := resolveSchemaRef(requestSchema.Value.Items, doc)
+				if itemSchema.Value.Type.Is("object") {
+					embeddedObjects = getEmbeddedKeysFromSchema(requestSchema.Value.Items)
+				}
 			}
+		}
 
-			// Initialize options for fetch
-			buf.WriteString("    let requestOptions: RequestInit = {\n")
-			buf.WriteString(fmt.Sprintf("      method: '%s',\n", strings.ToUpper(methodDefinition.HTTPMethod)))
-			buf.WriteString("      headers: {\n")
-			buf.WriteString("        'Content-Type': 'application/json',\n")
-			buf.WriteString("        'x-gocart-sdk-version': SDK_VERSION,\n")
-			buf.WriteString("        // Add other headers like authentication here\n")
-			buf.WriteString("      },\n")
-
-			if _, ok := methodDefinition.Arguments.GetPayloadParam(); ok {
-				buf.WriteString("      body: JSON.stringify(body),\n")
+		// write it (sorted alphabetically)
+		sort.Strings(embeddedObjects)
+		var bufEmbedded bytes.Buffer
+		bufEmbedded.WriteString("[")
+		for i, eo := range embeddedObjects {
+			bufEmbedded.WriteString(fmt.Sprintf("'%s'", eo))
+			if i < len(embeddedObjects)-1 {
+				bufEmbedded.WriteString(", ")
 			}
+		}
+		bufEmbedded.WriteString("]")
+		buf.WriteString(fmt.Sprintf("		const embeddedObjects: string[] = %v;\n", bufEmbedded.String()))
+		if param, ok := methodDefinition.Arguments.GetPayloadParam(); ok {
+			buf.WriteString(fmt.Sprintf("		const body = toApiType(%s, embeddedObjects);\n", param.Name))
+		}
 
-			buf.WriteString("      signal: options?.signal,\n")
-			buf.WriteString("    };\n")
-		}
-
-		if methodDefinition.OperationRef.RequestBody != nil && methodDefinition.OperationRef.RequestBody.Value != nil && methodDefinition.OperationRef.RequestBody.Value.Content["multipart/form-data"] != nil {
-			buf.Write([]byte("    // This is a multipart/form-data request\n"))
-			buf.Write([]byte("    // We need to create a FormData object and append fields to it\n"))
-			buf.Write([]byte("    let formData = new FormData();\n"))
-			buf.Write([]byte("    // Add form fields to formData\n"))
-			// iterate over responseTypeRef properties, is it is an object, json.stringify, else append to formData
-			for pName, pSchema := range methodDefinition.OperationRef.RequestBody.Value.Content["multipart/form-data"].Schema.Value.Properties {
-				if pSchema.Value.Type.Is("object") {
-					buf.WriteString(fmt.Sprintf("    if (req.%s) {\n", toCamelCase(pName)))
-					buf.WriteString(fmt.Sprintf("      formData.append('%s', JSON.stringify(req.%s));\n", pName, toCamelCase(pName)))
-					buf.WriteString("    }\n")
-				} else {
-					buf.WriteString(fmt.Sprintf("    if (req.%s !== undefined && req.%s !== null) {\n", toCamelCase(pName), toCamelCase(pName)))
-					buf.WriteString(fmt.Sprintf("      formData.append('%s', req.%s);\n", pName, toCamelCase(pName)))
-					buf.WriteString("    }\n")
-				}
-			}
+		// Initialize options for fetch
+		buf.WriteString("    let requestOptions: RequestInit = {\n")
+		buf.WriteString(fmt.Sprintf("      method: '%s',\n", strings.ToUpper(methodDefinition.HTTPMethod)))
+		buf.WriteString("      headers: {\n")
+		buf.WriteString("        'Content-Type': 'application/json',\n")
+		buf.WriteString(fmt.Sprintf("        %s: SDK_VERSION,\n", quoteLiteral(generatorOptions.SDKVersionHeader)))
+		if hasInputParams {
+			buf.WriteString("        ...paramHeaders,\n")
+		}
+		buf.WriteString("        ...callOptions.headers,\n")
+		buf.WriteString("      },\n")
+
+		if _, ok := methodDefinition.Arguments.GetPayloadParam(); ok {
+			buf.WriteString("      body: JSON.stringify(body),\n")
+		}
 
-			buf.WriteString("    // Configure the fetch options\n")
-			buf.WriteString("    let requestOptions: RequestInit = {\n")
-			buf.WriteString(fmt.Sprintf("      method: '%s',\n", strings.ToUpper(methodDefinition.HTTPMethod)))
-			buf.WriteString("      headers: {\n")
-			buf.WriteString("        // Do not set 'Content-Type' header when sending FormData\n")
-			buf.WriteString("        // The browser will automatically set it, including the boundary\n")
-			buf.WriteString("        'Accept': 'application/json',\n")
-			buf.WriteString("        'x-gocart-sdk-version': SDK_VERSION,\n")
-			buf.WriteString("        // Add other headers like authentication here\n")
-			buf.WriteString("      },\n")
-			buf.WriteString("      body: formData,\n")
-			buf.WriteString("      signal: options?.signal,\n")
-			buf.WriteString("    };\n")
+		buf.WriteString("      signal: timeout.signal,\n")
+		buf.WriteString("    };\n")
+	} else if hasMultipartBody {
+		buf.Write([]byte("    // This is a multipart/form-data request\n"))
+		buf.Write([]byte("    // We need to create a FormData object and append fields to it\n"))
+		buf.Write([]byte("    let formData = new FormData();\n"))
+		buf.Write([]byte("    // Add form fields to formData\n"))
+		// iterate over responseTypeRef properties, is it is an object, json.stringify, else append to formData
+		for pName, pSchema := range methodDefinition.OperationRef.RequestBody.Value.Content["multipart/form-data"].Schema.Value.Properties {
+			if pSchema.Value.Type.Is("object") {
+				buf.WriteString(fmt.Sprintf("    if (req.%s) {\n", toCamelCase(pName)))
+				buf.WriteString(fmt.Sprintf("      formData.append('%s', JSON.stringify(req.%s));\n", pName, toCamelCase(pName)))
+				buf.WriteString("    }\n")
+			} else {
+				buf.WriteString(fmt.Sprintf("    if (req.%s !== undefined && req.%s !== null) {\n", toCamelCase(pName), toCamelCase(pName)))
+				buf.WriteString(fmt.Sprintf("      formData.append('%s', req.%s);\n", pName, toCamelCase(pName)))
+				buf.WriteString("    }\n")
+			}
+		}
 
+		buf.WriteString("    // Configure the fetch options\n")
+		buf.WriteString("    let requestOptions: RequestInit = {\n")
+		buf.WriteString(fmt.Sprintf("      method: '%s',\n", strings.ToUpper(methodDefinition.HTTPMethod)))
+		buf.WriteString("      headers: {\n")
+		buf.WriteString("        // Do not set 'Content-Type' header when sending FormData\n")
+		buf.WriteString("        // The browser will automatically set it, including the boundary\n")
+		buf.WriteString("        'Accept': 'application/json',\n")
+		buf.WriteString(fmt.Sprintf("        %s: SDK_VERSION,\n", quoteLiteral(generatorOptions.SDKVersionHeader)))
+		if hasInputParams {
+			buf.WriteString("        ...paramHeaders,\n")
 		}
+		buf.WriteString("        ...callOptions.headers,\n")
+		buf.WriteString("      },\n")
+		buf.WriteString("      body: formData,\n")
+		buf.WriteString("      signal: timeout.signal,\n")
+		buf.WriteString("    };\n")
 	} else {
 		// Initialize options for fetch
 		buf.WriteString("    let requestOptions: RequestInit = {\n")
 		buf.WriteString(fmt.Sprintf("      method: '%s',\n", strings.ToUpper(methodDefinition.HTTPMethod)))
 		buf.WriteString("      headers: {\n")
 		buf.WriteString("        'Content-Type': 'application/json',\n")
-		buf.WriteString("        'x-gocart-sdk-version': SDK_VERSION,\n")
-		buf.WriteString("        // Add other headers like authentication here\n")
+		buf.WriteString(fmt.Sprintf("        %s: SDK_VERSION,\n", quoteLiteral(generatorOptions.SDKVersionHeader)))
+		if hasInputParams {
+			buf.WriteString("        ...paramHeaders,\n")
+		}
+		buf.WriteString("        ...callOptions.headers,\n")
 		buf.WriteString("      },\n")
-		buf.WriteString("      signal: options?.signal,\n")
+		buf.WriteString("      signal: timeout.signal,\n")
 		buf.WriteString("    };\n")
 	}
 
@@ -873,7 +1457,7 @@ func generateMethod(doc *openapi3.T, methodDefinition MethodDefinition) string {
 		buf.WriteString("    if (params.totalCount) {\n")
 		buf.WriteString("      requestOptions.headers = {\n")
 		buf.WriteString("        ...requestOptions.headers,\n")
-		buf.WriteString("        'Collection-Total': 'include'\n")
+		buf.WriteString(fmt.Sprintf("        %s: 'include'\n", quoteLiteral(generatorOptions.TotalCountHeader)))
 		buf.WriteString("      }\n")
 		buf.WriteString("    }\n")
 	}
@@ -883,35 +1467,28 @@ func generateMethod(doc *openapi3.T, methodDefinition MethodDefinition) string {
 	if methodDefinition.Arguments.HasParam("params") && methodDefinition.QueryParams["query"] != nil {
 		paramName := "params"
 
-		buf.WriteString("    const queryString = new URLSearchParams();\n")
+		buf.WriteString("    const queryString = new QueryString();\n")
 
-		// Separate filter, sort, and page parameters
-		var filterParams, sortParams, pageParams, includeParams []QueryParameter
+		// Separate filter, sort, and page parameters, then the other plain and bracketed parameters
+		var filterParams, sortParams, pageParams, includeParams, plainParams []QueryParameter
+		bracketParams := make(map[string][]QueryParameter)
 		for _, qp := range methodDefinition.QueryParams["query"] {
 			switch {
 			case strings.HasPrefix(qp.Name, "filter["):
-				filterParams = append(filterParams, QueryParameter{
-					Name:        qp.Name, // Keep original parameter name for query string
-					In:          "query",
-					Description: qp.Description,
-					Schema:      qp.Schema,
-					Required:    qp.Required,
-					SDKType:     qp.SDKType,
-				})
+				filterParams = append(filterParams, qp) // Keep original parameter name for query string
 			case qp.Name == "sort":
 				sortParams = append(sortParams, qp)
 			case strings.HasPrefix(qp.Name, "page["):
-				pageParams = append(pageParams, QueryParameter{
-					Name:        stripPageParams(qp.Name),
-					In:          "query",
-					Description: qp.Description,
-					Schema:      qp.Schema,
-					Required:    qp.Required,
-				})
+				pp := qp
+				pp.Name = stripPageParams(qp.Name)
+				pageParams = append(pageParams, pp)
 			case qp.Name == "include":
 				includeParams = append(includeParams, qp)
+			case isBracketParam(qp.Name):
+				base, _ := splitBracketParam(qp.Name)
+				bracketParams[base] = append(bracketParams[base], qp)
 			default:
-				// Handle other query parameters if any
+				plainParams = append(plainParams, qp)
 			}
 		}
 
@@ -925,7 +1502,7 @@ func generateMethod(doc *openapi3.T, methodDefinition MethodDefinition) string {
 				snakeKey := toSnakeCase(key)
 				queryParamName := fmt.Sprintf("filter[%s]", snakeKey)
 
-				if fp.SDKType == "DateRange" || fp.SDKType == "NumberRange" || fp.SDKType == "CurrencyRange" {
+				if contains(rangeSDKTypes, fp.SDKType) {
 					buf.WriteString(fmt.Sprintf("      if (%s.filter[\"%s\"] !== undefined && %s.filter[\"%s\"] !== null) {\n", paramName, camelKey, paramName, camelKey))
 					buf.WriteString(generateRangeQueryBuilder(paramName, camelKey, fp.SDKType, queryParamName))
 					buf.WriteString("      }\n")
@@ -933,7 +1510,14 @@ func generateMethod(doc *openapi3.T, methodDefinition MethodDefinition) string {
 					// Handle other filter types (string, boolean, uuid, etc.)
 					buf.WriteString(fmt.Sprintf("      if (%s.filter[\"%s\"] !== undefined && %s.filter[\"%s\"] !== null) {\n", paramName, camelKey, paramName, camelKey))
 					buf.WriteString(fmt.Sprintf("        const value = %s.filter[\"%s\"];\n", paramName, camelKey))
-					buf.WriteString(fmt.Sprintf("        queryString.append('%s', this.formatFilterValue(value));\n", queryParamName))
+					if usesQuerySerializer(fp) {
+						buf.WriteString("        const formatted = Array.isArray(value) ? value.map((v: any) => this.formatFilterValue(v)) : value;\n")
+						buf.WriteString(fmt.Sprintf("        %s\n", queryAppendStatement(fp, queryParamName, "formatted")))
+					} else if fp.AllowReserved {
+						buf.WriteString(fmt.Sprintf("        queryString.append('%s', this.formatFilterValue(value), true);\n", queryParamName))
+					} else {
+						buf.WriteString(fmt.Sprintf("        queryString.append('%s', this.formatFilterValue(value));\n", queryParamName))
+					}
 					buf.WriteString("      }\n")
 				}
 			}
@@ -945,8 +1529,8 @@ func generateMethod(doc *openapi3.T, methodDefinition MethodDefinition) string {
 			for _, sp := range sortParams {
 				camelSP := toCamelCase(sp.Name)
 				buf.WriteString(fmt.Sprintf("    if (%s.%s !== undefined && %s.%s !== null) {\n", paramName, camelSP, paramName, camelSP))
-				// join array and replace camelCase with snake_case
-				buf.WriteString(fmt.Sprintf("      queryString.append('%s', %s.%s.map((v)=> v.replace(/([A-Z])/g, '_$1').toLowerCase()).join(','));\n", sp.Name, paramName, camelSP))
+				// Replace camelCase with snake_case
+				buf.WriteString(fmt.Sprintf("      %s\n", listAppendStatement(sp, fmt.Sprintf("%s.%s.map((v) => v.replace(/([A-Z])/g, '_$1').toLowerCase())", paramName, camelSP))))
 				buf.WriteString("    }\n")
 			}
 		}
@@ -957,21 +1541,41 @@ func generateMethod(doc *openapi3.T, methodDefinition MethodDefinition) string {
 			for _, pp := range pageParams {
 				camelPP := toCamelCase(pp.Name)
 				buf.WriteString(fmt.Sprintf("      if (%s.page.%s !== undefined && %s.page.%s !== null) {\n", paramName, camelPP, paramName, camelPP))
-				buf.WriteString(fmt.Sprintf("        queryString.append('page[%s]', String(%s.page.%s));\n", pp.Name, paramName, camelPP))
+				buf.WriteString(fmt.Sprintf("        %s\n", queryAppendStatement(pp, "page["+pp.Name+"]", paramName+".page."+camelPP)))
 				buf.WriteString("      }\n")
 			}
 			buf.WriteString("    }\n")
 		}
 
-		if len(includeParams) > 0 {
+		for _, ip := range includeParams {
 			buf.WriteString(fmt.Sprintf("    if (%s.include) {\n", paramName))
-			buf.WriteString("      queryString.append('include', params.include.map((v) => {\n")
-			buf.WriteString("        // First handle path segments with dots\n")
-			buf.WriteString("        return v.split('.').map(segment => {\n")
-			buf.WriteString("          // Convert camelCase or PascalCase to snake_case\n")
-			buf.WriteString("          return segment.replace(/([a-z])([A-Z])/g, '$1_$2').toLowerCase();\n")
-			buf.WriteString("        }).join('.');\n")
-			buf.WriteString("      }).join(','));\n")
+			buf.WriteString("      // Convert the camelCase or PascalCase segments of the include paths to snake_case\n")
+			buf.WriteString(fmt.Sprintf("      %s\n", listAppendStatement(ip, fmt.Sprintf("%s.include.map((v) => v.split('.').map((segment) => segment.replace(/([a-z])([A-Z])/g, '$1_$2').toLowerCase()).join('.'))", paramName))))
+			buf.WriteString("    }\n")
+		}
+
+		// Handle the other parameters, sent as they are named in the spec
+		for _, qp := range plainParams {
+			value := paramName + propertyAccessor(toCamelCase(qp.Name))
+			buf.WriteString(fmt.Sprintf("    if (%s !== undefined && %s !== null) {\n", value, value))
+			buf.WriteString(fmt.Sprintf("      %s\n", queryAppendStatement(qp, qp.Name, value)))
+			buf.WriteString("    }\n")
+		}
+
+		for _, base := range sortedKeys(bracketParams) {
+			group := paramName + propertyAccessor(toCamelCase(base))
+			buf.WriteString(fmt.Sprintf("    if (%s) {\n", group))
+			for _, qp := range bracketParams[base] {
+				_, key := splitBracketParam(qp.Name)
+				value := group + propertyAccessor(toCamelCase(key))
+				buf.WriteString(fmt.Sprintf("      if (%s !== undefined && %s !== null) {\n", value, value))
+				if isSparseFieldsParam(qp) {
+					buf.WriteString(fmt.Sprintf("        %s\n", generateSparseFieldsParam(qp, value)))
+				} else {
+					buf.WriteString(fmt.Sprintf("        %s\n", queryAppendStatement(qp, qp.Name, value)))
+				}
+				buf.WriteString("      }\n")
+			}
 			buf.WriteString("    }\n")
 		}
 
@@ -980,40 +1584,21 @@ func generateMethod(doc *openapi3.T, methodDefinition MethodDefinition) string {
 		buf.WriteString("    let finalUrl = url;\n")
 	}
 
-	// Make the HTTP request
-	buf.WriteString("    requestOptions = this.context.setHttpRequestHeaders(requestOptions);\n")
-	buf.WriteString("    const response = await this.executeRequest(finalUrl, requestOptions);\n")
-	buf.WriteString("\n")
-	buf.WriteString("    if (response.status === 204) {\n")
-	if methodDefinition.ResponseType == "void" {
-		buf.WriteString("      return;\n")
-	} else {
-		buf.WriteString("      return {} as any;\n")
+	if methodDefinition.Pagination != nil {
+		buf.WriteString("    if (callOptions.pageUrl) {\n")
+		buf.WriteString("      finalUrl = new URL(callOptions.pageUrl, finalUrl).toString();\n")
+		buf.WriteString("    }\n")
 	}
-	buf.WriteString("    }\n\n")
-	buf.WriteString("    if (!response.ok) {\n")
-	buf.WriteString("      const errMessage = await response.json();\n")
-	buf.WriteString("      const err = toClientType(errMessage);\n")
-	buf.WriteString("      throw new ApiError(err.code, err.message, err.fieldErrors);\n")
-	buf.WriteString("    }\n")
-	// Handle No Content responses (e.g., 204 No Content)
-	if methodDefinition.ResponseType == "void" {
-		buf.WriteString("    return;\n")
-	} else if methodDefinition.ResponseType == "Blob" {
-		buf.WriteString("    // Handle binary response\n")
-		buf.WriteString("    const blob = await response.blob();\n")
-		buf.WriteString("    return blob;\n")
-	} else if methodDefinition.ResponseContentType == "text/html" {
-		buf.WriteString("    // Handle HTML response\n")
-		buf.WriteString("    const html = await response.text();\n")
-		buf.WriteString("    return html;\n")
-	} else {
-		buf.WriteString("    const data = await response.json();\n")
-		buf.WriteString("    // Transform keys to camelCase and recursively convert nested objects\n")
-		buf.WriteString("    return toClientType(data);\n")
+	buf.WriteString("    finalUrl = applyQuery(finalUrl, callOptions.query);\n")
+
+	// Apply the credentials of the security schemes required by the operation
+	if len(methodDefinition.Security) > 0 {
+		buf.WriteString(fmt.Sprintf("    ({ url: finalUrl, options: requestOptions } = await this.auth.apply(%s, finalUrl, requestOptions, callOptions.fetch));\n", securityRequirementsLiteral(methodDefinition.Security)))
 	}
 
-	buf.WriteString("  }\n")
+	// Make the HTTP request
+	buf.WriteString("    requestOptions = this.context.setHttpRequestHeaders(requestOptions);\n")
+	buf.WriteString("    const response = await this.executeRequest(finalUrl, requestOptions, callOptions.fetch);\n")
 
 	return buf.String()
 }
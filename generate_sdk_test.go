@@ -1,10 +1,16 @@
 package main
 
 import (
+	"bytes"
+	"fmt"
 	"io/ioutil"
+	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/stretchr/testify/assert"
@@ -772,6 +778,2497 @@ func TestComprehensiveFiltersGeneration(t *testing.T) {
 	}
 }
 
+func TestPathParameterArguments(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /carts/{cart_id}/items/{item_id}:
+    parameters:
+      - in: path
+        name: cart_id
+        required: true
+        schema:
+          type: string
+          format: uuid
+    patch:
+      operationId: updateCartItem
+      parameters:
+        - in: path
+          name: item_id
+          required: true
+          schema:
+            type: integer
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                quantity:
+                  type: integer
+      responses:
+        '200':
+          description: Success
+    delete:
+      operationId: deleteCartItem
+      parameters:
+        - in: path
+          name: item_id
+          required: true
+          schema:
+            type: integer
+      responses:
+        '204':
+          description: No content
+  /carts/{cart_id}/checkout:
+    post:
+      operationId: checkoutCart
+      parameters:
+        - in: path
+          name: cart_id
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Success
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	sdkString := string(generateSDK(doc, []TypeDefinition{}, []ParamDefinition{}))
+
+	assert.Contains(t, sdkString, "public async updateCartItem(cartId: string, itemId: number, req: UpdateCartItemRequest, options?: RequestOptions)")
+	assert.Contains(t, sdkString, "public async deleteCartItem(cartId: string, itemId: number, options?: RequestOptions)")
+	assert.Contains(t, sdkString, "public async checkoutCart(cartId: string, options?: RequestOptions)")
+	assert.Contains(t, sdkString, "const url = `${this.baseUrl}/carts/${cartId}/items/${itemId}`;")
+	assert.Contains(t, sdkString, "const url = `${this.baseUrl}/carts/${cartId}/checkout`;")
+}
+
+func TestTypedErrorResponses(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Cart:
+      type: object
+      properties:
+        id:
+          type: string
+    CartConflict:
+      type: object
+      properties:
+        conflicting_cart_id:
+          type: string
+paths:
+  /carts:
+    post:
+      operationId: createCart
+      responses:
+        '201':
+          description: Created
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Cart'
+        '409':
+          description: Conflict
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/CartConflict'
+        '422':
+          description: Validation failed
+          content:
+            application/problem+json:
+              schema:
+                type: object
+                properties:
+                  field_errors:
+                    type: array
+                    items:
+                      type: string
+        default:
+          description: Unexpected error
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	typeDefinitions := getTypeDefinitions(doc)
+	paramDefinitions := getParamDefinitions(doc)
+	sdkString := string(generateSDK(doc, typeDefinitions, paramDefinitions))
+	typesString := string(generateTypes(doc, typeDefinitions))
+
+	// Error unions are exported per operation and their bodies imported from types.ts
+	// The catch-all member excludes the declared statuses, so that err.status === 409 narrows the body to CartConflict
+	assert.Contains(t, sdkString, "export type CreateCartError = ApiError<409, CartConflict> | ApiError<422, CreateCart422Error> | ApiError<Exclude<ErrorStatus, 409 | 422>, unknown>;")
+	assert.Contains(t, sdkString, "import { ApiError, ErrorStatus } from './error';\n")
+	assert.Contains(t, sdkString, "  CartConflict,\n")
+	assert.Contains(t, sdkString, "  CreateCart422Error,\n")
+	assert.Contains(t, sdkString, "   * @throws {CreateCartError}\n")
+	assert.Contains(t, sdkString, "throw new ApiError(response.status, err);")
+
+	// Inline error bodies are generated in types.ts
+	assert.Contains(t, typesString, "export interface CreateCart422Error {\n  fieldErrors?: string[];\n}")
+
+	// Ranges exclude the statuses declared in them, default the statuses and ranges declared
+	errorResponses := []ErrorResponseDefinition{{Status: "404"}, {Status: "409"}, {Status: "4XX"}, {Status: "503"}, {Status: "5XX"}, {Status: "default"}}
+	assert.Equal(t, "409", errorStatusType("409", errorResponses))
+	assert.Equal(t, "Exclude<ClientErrorStatus, 404 | 409>", errorStatusType("4XX", errorResponses))
+	assert.Equal(t, "Exclude<ServerErrorStatus, 503>", errorStatusType("5XX", errorResponses))
+	assert.Equal(t, "Exclude<ErrorStatus, 404 | 409 | ClientErrorStatus | 503 | ServerErrorStatus>", errorStatusType("default", errorResponses))
+	assert.Equal(t, "ClientErrorStatus", errorStatusType("4XX", []ErrorResponseDefinition{{Status: "4XX"}}))
+
+	// The status types are exported by the error runtime module
+	runtimeFiles, err := getRuntimeFiles()
+	assert.NoError(t, err)
+	for _, f := range runtimeFiles {
+		if f.Name == "error.ts" {
+			for _, name := range []string{"ErrorStatus", "RedirectionStatus", "ClientErrorStatus", "ServerErrorStatus"} {
+				assert.Contains(t, string(f.Content), "export type "+name+" =")
+			}
+		}
+	}
+}
+
+func TestAllOfComposition(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    BaseEntity:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+        created_at:
+          type: string
+          format: date-time
+    ProductFields:
+      type: object
+      properties:
+        name:
+          type: string
+    Product:
+      allOf:
+        - $ref: '#/components/schemas/BaseEntity'
+        - $ref: '#/components/schemas/ProductFields'
+        - type: object
+          required: [price, created_at]
+          properties:
+            price:
+              type: number
+            variants:
+              type: array
+              items:
+                allOf:
+                  - $ref: '#/components/schemas/BaseEntity'
+                  - $ref: '#/components/schemas/ProductFields'
+paths: {}
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	typesString := string(generateTypes(doc, getTypeDefinitions(doc)))
+
+	assert.Contains(t, typesString, "export type Product = BaseEntity & Required<Pick<BaseEntity, 'createdAt'>> & ProductFields & {\n"+
+		"  price: number;\n"+
+		"  variants?: (BaseEntity & ProductFields)[];\n"+
+		"};")
+}
+
+func TestOneOfDiscriminatedUnion(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    CardPayment:
+      type: object
+      required: [type]
+      properties:
+        type:
+          type: string
+        last_four:
+          type: string
+    BankTransferPayment:
+      type: object
+      required: [type]
+      properties:
+        type:
+          type: string
+        iban:
+          type: string
+    PaymentMethod:
+      oneOf:
+        - $ref: '#/components/schemas/CardPayment'
+        - $ref: '#/components/schemas/BankTransferPayment'
+      discriminator:
+        propertyName: type
+        mapping:
+          card: '#/components/schemas/CardPayment'
+          bank_transfer: '#/components/schemas/BankTransferPayment'
+paths:
+  /payment-methods/{id}:
+    get:
+      operationId: getPaymentMethod
+      parameters:
+        - in: path
+          name: id
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/PaymentMethod'
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	typeDefinitions := getTypeDefinitions(doc)
+	typesString := string(generateTypes(doc, typeDefinitions))
+	sdkString := string(generateSDK(doc, typeDefinitions, getParamDefinitions(doc)))
+
+	assert.Contains(t, typesString, "export type PaymentMethod = (CardPayment & { type: 'card' }) | (BankTransferPayment & { type: 'bank_transfer' });")
+	assert.Contains(t, typesString, "export function isCardPayment(value: PaymentMethod): value is Extract<PaymentMethod, { type: 'card' }> {\n  return value.type === 'card';\n}")
+	assert.Contains(t, typesString, "export function isBankTransferPayment(value: PaymentMethod): value is Extract<PaymentMethod, { type: 'bank_transfer' }> {")
+
+	// The union is preserved as the response type
+	assert.Contains(t, sdkString, "Promise<PaymentMethod>")
+}
+
+func TestAdditionalPropertiesGeneration(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Translations:
+      type: object
+      additionalProperties:
+        type: string
+    Metadata:
+      type: object
+      additionalProperties: true
+    Sealed:
+      type: object
+      additionalProperties: false
+    Product:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+        attributes:
+          type: object
+          additionalProperties:
+            type: array
+            items:
+              type: string
+        name:
+          type: string
+      additionalProperties:
+        type: number
+paths: {}
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	typesString := string(generateTypes(doc, getTypeDefinitions(doc)))
+
+	assert.Contains(t, typesString, "export type Translations = Record<string, string>;")
+	assert.Contains(t, typesString, "export type Metadata = Record<string, any>;")
+	assert.Contains(t, typesString, "export interface Sealed {\n}")
+	assert.Contains(t, typesString, "  attributes?: Record<string, string[]>;\n")
+	assert.Contains(t, typesString, "  [key: string]: number | Record<string, string[]> | string | undefined;\n")
+
+	// additionalProperties: false is honoured in strict mode
+	generatorOptions.StrictAdditionalProperties = true
+	defer func() { generatorOptions.StrictAdditionalProperties = false }()
+
+	typesString = string(generateTypes(doc, getTypeDefinitions(doc)))
+	assert.Contains(t, typesString, "export type Sealed = Record<string, never>;")
+}
+
+func TestInlineObjectGeneration(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Order:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+        shipping_address:
+          type: object
+          required: [city]
+          properties:
+            city:
+              type: string
+            geo:
+              type: object
+              properties:
+                lat:
+                  type: number
+                lng:
+                  type: number
+        lines:
+          type: array
+          items:
+            type: object
+            properties:
+              sku:
+                type: string
+paths:
+  /orders:
+    post:
+      operationId: createOrder
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                billing_address:
+                  type: object
+                  properties:
+                    city:
+                      type: string
+      responses:
+        '201':
+          description: Created
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	typesString := string(generateTypes(doc, getTypeDefinitions(doc)))
+
+	assert.Contains(t, typesString, "export interface Order {\n"+
+		"  id: string;\n"+
+		"  lines?: {\n"+
+		"    sku?: string;\n"+
+		"  }[];\n"+
+		"  shippingAddress?: {\n"+
+		"    city: string;\n"+
+		"    geo?: {\n"+
+		"      lat?: number;\n"+
+		"      lng?: number;\n"+
+		"    };\n"+
+		"  };\n"+
+		"}")
+	assert.Contains(t, typesString, "export interface CreateOrderRequest {\n"+
+		"  billingAddress?: {\n"+
+		"    city?: string;\n"+
+		"  };\n"+
+		"}")
+}
+
+func TestJSDocGeneration(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Product:
+      type: object
+      description: A product sold in the store.
+      required: [id]
+      properties:
+        id:
+          type: string
+          format: uuid
+          description: Unique identifier.
+          example: 7b0c3c4e-52b4-4a63-9f4a-0c5f5c3a1d2e
+        name:
+          type: string
+          minLength: 1
+          maxLength: 255
+          pattern: '^[^*/]+$'
+        legacy_code:
+          type: string
+          deprecated: true
+        stock:
+          type: integer
+          minimum: 0
+          maximum: 10000
+          default: 0
+paths:
+  /products:
+    get:
+      operationId: listProducts
+      summary: List products
+      description: Returns the products visible in the current store.
+      tags: [Products]
+      deprecated: true
+      externalDocs:
+        url: https://docs.example.com/products
+        description: Products guide
+      responses:
+        '200':
+          description: Success
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	typeDefinitions := getTypeDefinitions(doc)
+	typesString := string(generateTypes(doc, typeDefinitions))
+	sdkString := string(generateSDK(doc, typeDefinitions, getParamDefinitions(doc)))
+
+	assert.Contains(t, typesString, "/**\n * A product sold in the store.\n */\nexport interface Product {\n")
+	assert.Contains(t, typesString, "  /**\n"+
+		"   * Unique identifier.\n"+
+		"   * @format uuid\n"+
+		"   * @example \"7b0c3c4e-52b4-4a63-9f4a-0c5f5c3a1d2e\"\n"+
+		"   */\n"+
+		"  id: string;\n")
+	assert.Contains(t, typesString, "  /**\n   * @deprecated\n   */\n  legacyCode?: string;\n")
+	assert.Contains(t, typesString, "   * @minLength 1\n   * @maxLength 255\n   * @pattern ^[^*\\/]+$\n")
+	assert.Contains(t, typesString, "   * @default 0\n   * @minimum 0\n   * @maximum 10000\n")
+
+	assert.Contains(t, sdkString, "  /**\n"+
+		"   * List products\n"+
+		"   *\n"+
+		"   * Returns the products visible in the current store.\n"+
+		"   * @deprecated\n"+
+		"   * @category Products\n"+
+		"   * @see {@link https://docs.example.com/products | Products guide}\n"+
+		"   * @param params ListProductsParams\n")
+}
+
+func TestGroupByTagResources(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /products:
+    get:
+      operationId: listProducts
+      tags: [Products]
+      responses:
+        '200':
+          description: Success
+  /products/{id}:
+    get:
+      operationId: getProduct
+      tags: [Products]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Success
+  /products/{id}/variants:
+    get:
+      operationId: listProductVariants
+      tags: [Products]
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Success
+  /order-items:
+    get:
+      operationId: listOrderItems
+      tags: [Order Items]
+      responses:
+        '200':
+          description: Success
+  /carts:
+    x-gocart-resource: Carts
+    post:
+      operationId: createCart
+      tags: [Checkout]
+      responses:
+        '201':
+          description: Created
+  /health:
+    get:
+      operationId: healthCheck
+      responses:
+        '200':
+          description: Success
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	typeDefinitions := getTypeDefinitions(doc)
+	paramDefinitions := getParamDefinitions(doc)
+
+	// Flat SDK by default
+	sdkString := string(generateSDK(doc, typeDefinitions, paramDefinitions))
+	assert.Contains(t, sdkString, "  public async listProducts(")
+	assert.Contains(t, sdkString, "  private async executeRequest(")
+	assert.NotContains(t, sdkString, "ApiResource")
+
+	defer func(options GeneratorOptions) { generatorOptions = options }(generatorOptions)
+	generatorOptions.GroupByTag = true
+
+	sdkString = string(generateSDK(doc, typeDefinitions, paramDefinitions))
+
+	// Sub-clients are exposed on the SDK and share its request pipeline
+	assert.Contains(t, sdkString, "  public readonly carts: CartsApi;\n")
+	assert.Contains(t, sdkString, "  public readonly orderItems: OrderItemsApi;\n")
+	assert.Contains(t, sdkString, "  public readonly products: ProductsApi;\n")
+	assert.Contains(t, sdkString, "    this.products = new ProductsApi(client);\n")
+	assert.Contains(t, sdkString, "abstract class ApiResource {\n")
+
+	// The members used by the sub-clients stay private, shared through the client interface
+	assert.Contains(t, sdkString, "  private async executeRequest(")
+	assert.Contains(t, sdkString, "  private baseUrl: string;\n")
+	assert.NotContains(t, sdkString, "@internal")
+	assert.Contains(t, sdkString, "interface GoCartSDKClient {\n"+
+		"  readonly baseUrl: string;\n"+
+		"  readonly context: InMemoryContext;\n"+
+		"  readonly defaults: DefaultRequestOptions;\n"+
+		"  formatFilterValue(value: any): string;\n"+
+		"  executeRequest(url: string, options: RequestInit, customFetch?: typeof fetch): Promise<Response>;\n"+
+		"}\n")
+	assert.Contains(t, sdkString, "    const client: GoCartSDKClient = {\n"+
+		"      get baseUrl() {\n        return sdk.baseUrl;\n      },\n")
+	assert.Contains(t, sdkString, "      executeRequest: (url: string, options: RequestInit, customFetch?: typeof fetch) => sdk.executeRequest(url, options, customFetch),\n")
+	assert.Contains(t, sdkString, "  protected executeRequest(url: string, options: RequestInit, customFetch?: typeof fetch): Promise<Response> {\n"+
+		"    return this.client.executeRequest(url, options, customFetch);\n")
+
+	// Method names drop the resource name
+	assert.Contains(t, sdkString, "export class ProductsApi extends ApiResource {\n")
+	assert.Contains(t, sdkString, "  public async list(params: ListProductsParams = {}")
+	assert.Contains(t, sdkString, "  public async get(id: string, params: GetProductParams = {}")
+	assert.Contains(t, sdkString, "  public async listVariants(id: string")
+	assert.Contains(t, sdkString, "export class OrderItemsApi extends ApiResource {\n")
+
+	// x-gocart-resource takes precedence over tags
+	assert.Contains(t, sdkString, "export class CartsApi extends ApiResource {\n")
+	assert.Contains(t, sdkString, "  public async create(")
+	assert.NotContains(t, sdkString, "CheckoutApi")
+
+	// Untagged operations stay on the SDK class
+	sdkClass := sdkString[strings.Index(sdkString, "export class GoCartSDK"):strings.Index(sdkString, "abstract class ApiResource")]
+	assert.Contains(t, sdkClass, "  public async healthCheck(")
+	assert.NotContains(t, sdkClass, "listProducts(")
+}
+
+func TestPaginationMethods(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Product:
+      type: object
+      properties:
+        id:
+          type: string
+    ProductList:
+      type: object
+      properties:
+        data:
+          type: array
+          items:
+            $ref: '#/components/schemas/Product'
+paths:
+  /products:
+    get:
+      operationId: listProducts
+      parameters:
+        - name: page[number]
+          in: query
+          schema:
+            type: integer
+        - name: page[size]
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: Success
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/ProductList'
+  /stores/{store_id}/orders:
+    get:
+      operationId: listStoreOrders
+      x-gocart-pagination: cursor
+      parameters:
+        - name: store_id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Success
+          content:
+            application/json:
+              schema:
+                type: object
+  /events:
+    get:
+      operationId: listEvents
+      x-gocart-pagination:
+        strategy: link
+        items: results
+      responses:
+        '200':
+          description: Success
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  results:
+                    type: array
+                    items:
+                      type: string
+  /logs:
+    get:
+      operationId: listLogs
+      x-gocart-pagination: none
+      parameters:
+        - name: page[number]
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: Success
+          content:
+            application/json:
+              schema:
+                type: object
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	typeDefinitions := getTypeDefinitions(doc)
+	sdkString := string(generateSDK(doc, typeDefinitions, getParamDefinitions(doc)))
+
+	assert.Contains(t, sdkString, "import { Page, PaginationOptions, collect, paginate } from './pagination';\n")
+	assert.Contains(t, sdkString, "  Product,\n")
+
+	// Page number strategy, with items typed from the response
+	assert.Contains(t, sdkString, "  private async listProductsPage(params: ListProductsParams, pageUrl?: string, options?: RequestOptions): Promise<Page<Product>> {\n")
+	assert.Contains(t, sdkString, "      return { items: data?.data ?? [], body: data, response };\n")
+	assert.Contains(t, sdkString, "  public async *iterateProducts(params: ListProductsParams = {}, options: PaginationOptions = {}): AsyncGenerator<Product, void, undefined> {\n"+
+		"    yield* paginate<Product>(\n"+
+		"      'page',\n"+
+		"      (cursor) => this.listProductsPage({ ...params, page: { ...params.page, number: cursor.number } }, cursor.url, options),\n"+
+		"      options,\n"+
+		"      params.page?.number,\n"+
+		"    );\n")
+	assert.Contains(t, sdkString, "  public async listProductsAll(params: ListProductsParams = {}, options: PaginationOptions = {}): Promise<Product[]> {\n"+
+		"    return collect(this.iterateProducts(params, options));\n")
+
+	// Cursor strategy follows the next page URL, path arguments are forwarded
+	assert.Contains(t, sdkString, "  public async *iterateStoreOrders(storeId: string, params: ListStoreOrdersParams = {}, options: PaginationOptions = {}): AsyncGenerator<any, void, undefined> {\n")
+	assert.Contains(t, sdkString, "      'cursor',\n      (cursor) => this.listStoreOrdersPage(storeId, params, cursor.url, options),\n")
+	assert.Contains(t, sdkString, "      if (callOptions.pageUrl) {\n        finalUrl = new URL(callOptions.pageUrl, finalUrl).toString();\n      }\n")
+
+	// Page fetchers reuse the request of their operation rather than building their own
+	assert.Contains(t, sdkString, "      const response = await this.listStoreOrders(storeId, params, { ...callOptions, signal: timeout.signal, timeoutMs: undefined, raw: true, pageUrl });\n")
+	assert.Equal(t, 1, strings.Count(sdkString, "/stores/${storeId}/orders`"))
+
+	// Link header strategy with a custom items property
+	assert.Contains(t, sdkString, "      'link',\n")
+	assert.Contains(t, sdkString, "      return { items: data?.results ?? [], body: data, response };\n")
+	assert.Contains(t, sdkString, "Promise<Page<string>>")
+
+	// Pagination can be disabled
+	assert.NotContains(t, sdkString, "iterateLogs")
+	assert.NotContains(t, sdkString, "listLogsAll")
+}
+
+func TestSecuritySchemeAuthentication(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+security:
+  - bearerAuth: []
+  - oauth2: [carts:write]
+components:
+  securitySchemes:
+    bearerAuth:
+      type: http
+      scheme: bearer
+      bearerFormat: JWT
+    basicAuth:
+      type: http
+      scheme: basic
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+      description: Store API key.
+    sessionCookie:
+      type: apiKey
+      in: cookie
+      name: session
+    oauth2:
+      type: oauth2
+      flows:
+        clientCredentials:
+          tokenUrl: https://auth.example.com/token
+          refreshUrl: https://auth.example.com/refresh
+          scopes:
+            carts:write: Write carts
+paths:
+  /carts:
+    post:
+      operationId: createCart
+      responses:
+        '201':
+          description: Created
+  /products:
+    get:
+      operationId: listProducts
+      security:
+        - apiKey: []
+          sessionCookie: []
+        - basicAuth: []
+      responses:
+        '200':
+          description: Success
+  /health:
+    get:
+      operationId: healthCheck
+      security: []
+      responses:
+        '200':
+          description: Success
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	sdkString := string(generateSDK(doc, getTypeDefinitions(doc), getParamDefinitions(doc)))
+
+	assert.Contains(t, sdkString, "import { AuthManager, BasicCredentials, OAuth2Credentials, SecurityScheme, TokenProvider } from './auth';\n")
+
+	// Typed credentials for every scheme
+	assert.Contains(t, sdkString, "export interface GoCartSDKAuth {\n")
+	assert.Contains(t, sdkString, "  /**\n   * Store API key.\n   * API key sent in the X-API-Key header\n   */\n  apiKey?: TokenProvider;\n")
+	assert.Contains(t, sdkString, "  basicAuth?: BasicCredentials;\n")
+	assert.Contains(t, sdkString, "   * HTTP bearer authentication (JWT)\n   */\n  bearerAuth?: TokenProvider;\n")
+	assert.Contains(t, sdkString, "  oauth2?: OAuth2Credentials;\n")
+	assert.Contains(t, sdkString, "export interface GoCartSDKOptions {\n  auth?: GoCartSDKAuth;\n\n")
+
+	// Security schemes consumed by the auth runtime
+	assert.Contains(t, sdkString, "  apiKey: { type: 'apiKey', in: 'header', name: 'X-API-Key' },\n")
+	assert.Contains(t, sdkString, "  basicAuth: { type: 'http', scheme: 'basic' },\n")
+	assert.Contains(t, sdkString, "  bearerAuth: { type: 'http', scheme: 'bearer' },\n")
+	assert.Contains(t, sdkString, "  oauth2: { type: 'oauth2', tokenUrl: 'https://auth.example.com/token', refreshUrl: 'https://auth.example.com/refresh' },\n")
+	assert.Contains(t, sdkString, "  sessionCookie: { type: 'apiKey', in: 'cookie', name: 'session' },\n")
+
+	assert.Contains(t, sdkString, "  constructor(baseUrl: string = 'https://api.orbita.al', options: GoCartSDKOptions = {}) {\n")
+	assert.Contains(t, sdkString, "    this.auth = new AuthManager(SECURITY_SCHEMES, options.auth);\n")
+	assert.Contains(t, sdkString, "  public setAuth(auth: GoCartSDKAuth): void {\n")
+
+	// Document security applies unless the operation overrides it
+	assert.Contains(t, sdkString, "    const url = `${this.baseUrl}/carts`;\n")
+	assert.Contains(t, sdkString, "    ({ url: finalUrl, options: requestOptions } = await this.auth.apply([['bearerAuth'], ['oauth2']], finalUrl, requestOptions, callOptions.fetch));\n")
+	assert.Contains(t, sdkString, "    ({ url: finalUrl, options: requestOptions } = await this.auth.apply([['apiKey', 'sessionCookie'], ['basicAuth']], finalUrl, requestOptions, callOptions.fetch));\n")
+
+	// security: [] skips authentication
+	healthCheck := sdkString[strings.Index(sdkString, "public async healthCheck("):]
+	healthCheck = healthCheck[:strings.Index(healthCheck, "\n  }\n")]
+	assert.NotContains(t, healthCheck, "this.auth.apply")
+
+	// Token requests use the fetch implementation of the request
+	runtimeFiles, err := getRuntimeFiles()
+	assert.NoError(t, err)
+	for _, f := range runtimeFiles {
+		if f.Name == "auth.ts" {
+			assert.Contains(t, string(f.Content), "    fetchImpl: typeof fetch = fetch,\n")
+			assert.Contains(t, string(f.Content), "const response = await fetchImpl(tokenUrl, {")
+
+			// Query API keys are appended without parsing the URL, which may be relative
+			assert.Contains(t, string(f.Content), "finalUrl = applyQuery(finalUrl, { [scheme.name]: value });")
+			assert.NotContains(t, string(f.Content), "new URL(")
+		}
+	}
+
+	// APIs without security schemes have no auth options
+	doc.Components.SecuritySchemes = nil
+	sdkString = string(generateSDK(doc, getTypeDefinitions(doc), getParamDefinitions(doc)))
+	assert.NotContains(t, sdkString, "AuthManager")
+	assert.NotContains(t, sdkString, "GoCartSDKAuth")
+}
+
+func TestServerConfiguration(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+servers:
+  - url: https://{region}.api.example.com/{version}/
+    variables:
+      region:
+        default: eu
+        enum: [eu, us]
+        description: Data center region.
+      version:
+        default: v1
+  - url: https://staging.api.example.com
+paths:
+  /products:
+    get:
+      operationId: listProducts
+      responses:
+        '200':
+          description: Success
+  /files:
+    servers:
+      - url: https://uploads.{region}.example.com
+        variables:
+          region:
+            default: eu
+    post:
+      operationId: uploadFile
+      responses:
+        '201':
+          description: Created
+  /status:
+    get:
+      operationId: getStatus
+      servers:
+        - url: https://status.example.com/
+      responses:
+        '200':
+          description: Success
+  /reports:
+    servers:
+      - url: /v2/
+    get:
+      operationId: listReports
+      responses:
+        '200':
+          description: Success
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	sdkString := string(generateSDK(doc, getTypeDefinitions(doc), getParamDefinitions(doc)))
+
+	assert.Contains(t, sdkString, "import { resolveRelativeUrl, resolveServerUrl } from './servers';\n")
+
+	// Server variables are typed, enum-constrained variables as unions
+	assert.Contains(t, sdkString, "export interface GoCartSDKServerVariables {\n"+
+		"  /**\n"+
+		"   * Data center region.\n"+
+		"   * @default \"eu\"\n"+
+		"   */\n"+
+		"  region?: 'eu' | 'us';\n"+
+		"\n"+
+		"  /**\n"+
+		"   * @default \"v1\"\n"+
+		"   */\n"+
+		"  version?: string;\n"+
+		"}\n")
+	assert.Contains(t, sdkString, "export interface GoCartSDKOptions {\n  serverVariables?: GoCartSDKServerVariables;\n\n")
+
+	// The default base URL is the first server
+	assert.Contains(t, sdkString, "  constructor(baseUrl?: string, options: GoCartSDKOptions = {}) {\n"+
+		"    this.serverVariables = { ...options.serverVariables };\n"+
+		"    this.baseUrl = baseUrl ?? resolveServerUrl('https://{region}.api.example.com/{version}/', { region: 'eu', version: 'v1' }, this.serverVariables);\n")
+	assert.Contains(t, sdkString, "    const url = `${this.baseUrl}/products`;\n")
+
+	// Path and operation servers override the document servers
+	assert.Contains(t, sdkString, "    const url = `${resolveServerUrl('https://uploads.{region}.example.com', { region: 'eu' }, this.serverVariables)}/files`;\n")
+	assert.Contains(t, sdkString, "    const url = `${'https://status.example.com'}/status`;\n")
+
+	// Relative server overrides are resolved against the base URL
+	assert.Contains(t, sdkString, "    const url = `${resolveRelativeUrl('/v2/', this.baseUrl)}/reports`;\n")
+
+	runtimeFiles, err := getRuntimeFiles()
+	assert.NoError(t, err)
+	for _, f := range runtimeFiles {
+		if f.Name == "servers.ts" {
+			assert.Contains(t, string(f.Content), "export function resolveRelativeUrl(url: string, baseUrl: string): string {\n")
+		}
+	}
+
+	// Servers without variables are used as is
+	doc.Servers = openapi3.Servers{{URL: "https://api.example.com/"}}
+	doc.Paths.Find("/files").Servers = nil
+	doc.Paths.Find("/reports").Servers = nil
+	sdkString = string(generateSDK(doc, getTypeDefinitions(doc), getParamDefinitions(doc)))
+	assert.Contains(t, sdkString, "  constructor(baseUrl: string = 'https://api.example.com', options: GoCartSDKOptions = {}) {\n")
+	assert.NotContains(t, sdkString, "resolveServerUrl")
+	assert.NotContains(t, sdkString, "from './servers'")
+}
+
+func TestRetryPolicyGeneration(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/date_range_input.yaml")
+	assert.NoError(t, err)
+
+	sdkString := string(generateSDK(doc, getTypeDefinitions(doc), getParamDefinitions(doc)))
+
+	// The retry policy is configured through the constructor options
+	assert.Contains(t, sdkString, "  retry?: RetryPolicy | false;\n")
+	assert.Contains(t, sdkString, "    this.retryPolicy = resolveRetryPolicy(options.retry);\n")
+
+	// Transient failures are retried with backoff
+	assert.Contains(t, sdkString, "    let response = await this.fetchWithRetry(finalUrl, currentOptions, customFetch);\n")
+	assert.Contains(t, sdkString, "        if (!shouldRetry(this.retryPolicy, method, attempt, undefined, error)) {\n")
+	assert.Contains(t, sdkString, "      await sleep(retryDelay(this.retryPolicy, attempt, response), options.signal);\n")
+
+	// Interceptor-driven retries are bounded
+	assert.Contains(t, sdkString, "  private async executeRequest(url: string, options: RequestInit, customFetch?: typeof fetch, interceptorRetries: number = 0): Promise<Response> {\n"+
+		"    if (interceptorRetries > MAX_INTERCEPTOR_RETRIES) {\n")
+	assert.Contains(t, sdkString, "          return this.executeRequest(result.url, result.options, customFetch, interceptorRetries + 1);\n")
+
+	runtimeFiles, err := getRuntimeFiles()
+	assert.NoError(t, err)
+	for _, f := range runtimeFiles {
+		if f.Name != "retry.ts" {
+			continue
+		}
+		retry := string(f.Content)
+		assert.Contains(t, retry, "retryableStatusCodes: [408, 429, 502, 503, 504],\n")
+		assert.Contains(t, retry, "retryableMethods: ['GET', 'HEAD', 'OPTIONS', 'PUT', 'DELETE'],\n")
+		assert.Contains(t, retry, "export function parseRetryAfter(")
+	}
+}
+
+func TestRequestOptionsGeneration(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Request Options API
+  version: 1.0.0
+paths:
+  /invoices:
+    get:
+      operationId: listInvoices
+      responses:
+        '200':
+          description: Invoices
+  /invoices/{invoice_id}:
+    get:
+      operationId: getInvoice
+      parameters:
+        - name: invoice_id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Invoice document
+          content:
+            application/pdf:
+              schema:
+                type: string
+                format: binary
+  /invoices/{invoice_id}/send:
+    post:
+      operationId: sendInvoice
+      parameters:
+        - name: invoice_id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '204':
+          description: Sent
+`
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	sdkString := string(generateSDK(doc, []TypeDefinition{}, []ParamDefinition{}))
+
+	// SDK-level defaults are passed to the constructor
+	assert.Contains(t, sdkString, "import { DefaultRequestOptions, RawRequestOptions, RequestOptions, applyQuery, mergeRequestOptions, withTimeout } from './request';\n")
+	assert.Contains(t, sdkString, "  defaults?: DefaultRequestOptions;\n")
+	assert.Contains(t, sdkString, "    this.defaults = options.defaults ?? {};\n")
+
+	// raw: true resolves to the Response
+	assert.Contains(t, sdkString, "  public getInvoice(invoiceId: string, params: GetInvoiceParams | undefined, options: RawRequestOptions): Promise<Response>;\n")
+	assert.Contains(t, sdkString, "  public getInvoice(invoiceId: string, params?: GetInvoiceParams, options?: RequestOptions): Promise<Blob>;\n")
+	// Optional arguments can be omitted when resolving to the parsed body
+	assert.Contains(t, sdkString, "  public listInvoices(params?: ListInvoicesParams, options?: RequestOptions): Promise<any>;\n")
+	assert.Contains(t, sdkString, "  public async getInvoice(invoiceId: string, params: GetInvoiceParams = {}, options?: RequestOptions): Promise<Blob | Response> {\n"+
+		"    const callOptions = mergeRequestOptions(this.defaults, options);\n"+
+		"    const timeout = withTimeout(callOptions.signal, callOptions.timeoutMs);\n"+
+		"    try {\n")
+	assert.Contains(t, sdkString, "      if (callOptions.raw) {\n        return response;\n      }\n")
+	assert.Contains(t, sdkString, "    } finally {\n      timeout.clear();\n    }\n")
+
+	// Headers, query, signal and fetch of the call are applied to the request
+	assert.Contains(t, sdkString, "          ...callOptions.headers,\n        },\n        signal: timeout.signal,\n")
+	assert.Contains(t, sdkString, "      finalUrl = applyQuery(finalUrl, callOptions.query);\n")
+	assert.Contains(t, sdkString, "      const response = await this.executeRequest(finalUrl, requestOptions, callOptions.fetch);\n")
+	assert.Contains(t, sdkString, "        response = await fetchImpl(url, options);\n")
+
+	// A POST without request body still declares its request options
+	assert.Contains(t, sdkString, "  public async sendInvoice(invoiceId: string, options?: RequestOptions): Promise<void | Response> {\n")
+	assert.Contains(t, sdkString, "      let requestOptions: RequestInit = {\n        method: 'POST',\n")
+}
+
+func TestResponseMetadataGeneration(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Response Metadata API
+  version: 1.0.0
+paths:
+  /products:
+    get:
+      operationId: listProducts
+      responses:
+        '200':
+          description: Products
+  /search:
+    get:
+      operationId: searchProducts
+      responses:
+        '200':
+          description: Matching products
+          headers:
+            collection-total:
+              schema:
+                type: integer
+  /products/{product_id}:
+    get:
+      operationId: getProduct
+      parameters:
+        - name: product_id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Product
+          headers:
+            ETag:
+              description: Version of the product
+              schema:
+                type: string
+            X-RateLimit-Remaining:
+              schema:
+                type: integer
+            X-Cache-Hit:
+              schema:
+                type: boolean
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  name:
+                    type: string
+    delete:
+      operationId: deleteProduct
+      parameters:
+        - name: product_id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '204':
+          description: Deleted
+`
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	sdkString := string(generateSDK(doc, []TypeDefinition{}, []ParamDefinition{}))
+
+	assert.Contains(t, sdkString, "import { ApiResponse, booleanHeader, numberHeader, stringHeader } from './response';\n")
+
+	// Declared response headers are typed
+	assert.Contains(t, sdkString, "export interface GetProductResponseHeaders {\n"+
+		"  /**\n   * Version of the product\n   * Header ETag\n   */\n"+
+		"  etag?: string;\n")
+	assert.Contains(t, sdkString, "  xCacheHit?: boolean;\n")
+	assert.Contains(t, sdkString, "  xRateLimitRemaining?: number;\n")
+
+	// The WithResponse variant reads the body through the raw response
+	assert.Contains(t, sdkString, "  public async getProductWithResponse(productId: string, params: GetProductParams = {}, options?: RequestOptions): Promise<ApiResponse<any, GetProductResponseHeaders>> {\n")
+	assert.Contains(t, sdkString, "      const response = await this.getProduct(productId, params, { ...callOptions, signal: timeout.signal, timeoutMs: undefined, raw: true });\n")
+	assert.Contains(t, sdkString, "      const data: any = response.status === 204 ? ({} as any) : toClientType(await response.json());\n")
+	assert.Contains(t, sdkString, "        headers: {\n"+
+		"          etag: stringHeader(response.headers, 'ETag'),\n"+
+		"          xCacheHit: booleanHeader(response.headers, 'X-Cache-Hit'),\n"+
+		"          xRateLimitRemaining: numberHeader(response.headers, 'X-RateLimit-Remaining'),\n"+
+		"        },\n")
+
+	// The total count is read from the configured header, when requested or declared
+	listProducts := sdkString[strings.Index(sdkString, "public async listProductsWithResponse("):]
+	assert.Contains(t, listProducts[:strings.Index(listProducts, "\n  }\n")], "        totalCount: numberHeader(response.headers, 'Collection-Total'),\n")
+	searchProducts := sdkString[strings.Index(sdkString, "public async searchProductsWithResponse("):]
+	assert.Contains(t, searchProducts[:strings.Index(searchProducts, "\n  }\n")], "        totalCount: numberHeader(response.headers, 'collection-total'),\n")
+	getProduct := sdkString[strings.Index(sdkString, "public async getProductWithResponse("):]
+	assert.NotContains(t, getProduct[:strings.Index(getProduct, "\n  }\n")], "totalCount")
+
+	defer func(options GeneratorOptions) { generatorOptions = options }(generatorOptions)
+	generatorOptions.TotalCountHeader = "X-Total-Count"
+	sdkString = string(generateSDK(doc, []TypeDefinition{}, []ParamDefinition{}))
+	assert.Contains(t, sdkString, "        totalCount: numberHeader(response.headers, 'X-Total-Count'),\n")
+	assert.Equal(t, 1, strings.Count(sdkString, "totalCount: numberHeader("))
+
+	// Operations without declared headers get an empty headers object
+	assert.Contains(t, sdkString, "  public async deleteProductWithResponse(productId: string, options?: RequestOptions): Promise<ApiResponse<void, {}>> {\n")
+	assert.Contains(t, sdkString, "      const data: void = undefined;\n")
+	assert.NotContains(t, sdkString, "DeleteProductResponseHeaders")
+}
+
+func TestConfigFile(t *testing.T) {
+	config, err := parseConfig([]byte(`
+doc: openapi.yaml
+output:
+  dir: ./generated
+  sdkFile: client.ts
+className: ShopClient
+defaultBaseUrl: https://api.example.com/
+headers:
+  sdkVersion: x-shop-sdk-version
+  totalCount: X-Total-Count
+includeFallback: [category, variants]
+includeDepth: 2
+features:
+  groupByTag: true
+  pagination: false
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, "openapi.yaml", config.Doc)
+	assert.Equal(t, OutputConfig{Dir: "./generated", SDKFile: "client.ts"}, config.Output)
+
+	options := defaultGeneratorOptions()
+	config.apply(&options)
+	assert.Equal(t, "ShopClient", options.ClassName)
+	assert.Equal(t, "https://api.example.com", options.DefaultBaseURL)
+	assert.Equal(t, "x-shop-sdk-version", options.SDKVersionHeader)
+	assert.Equal(t, "X-Total-Count", options.TotalCountHeader)
+	assert.Equal(t, []string{"category", "variants"}, options.IncludeFallback)
+	assert.Equal(t, 2, options.IncludeDepth)
+	assert.True(t, options.GroupByTag)
+	assert.False(t, options.Pagination)
+	// Features missing from the file keep their defaults
+	assert.True(t, options.WithResponse)
+	assert.False(t, options.StrictAdditionalProperties)
+
+	// An empty file keeps all the defaults
+	config, err = parseConfig([]byte(""))
+	assert.NoError(t, err)
+	options = defaultGeneratorOptions()
+	config.apply(&options)
+	assert.Equal(t, defaultGeneratorOptions(), options)
+
+	// An include depth of 0 disables the derived include values
+	config, err = parseConfig([]byte("includeDepth: 0\n"))
+	assert.NoError(t, err)
+	options = defaultGeneratorOptions()
+	config.apply(&options)
+	assert.Equal(t, 0, options.IncludeDepth)
+
+	// Unknown settings are rejected
+	_, err = parseConfig([]byte("classname: ShopClient\n"))
+	assert.ErrorContains(t, err, "field classname not found")
+
+	// All the invalid settings are reported
+	_, err = parseConfig([]byte(`
+output:
+  sdkFile: retry.ts
+className: shop-client
+defaultBaseUrl: /api
+headers:
+  sdkVersion: "x sdk version"
+includeFallback: ["parent", ""]
+includeDepth: -1
+`))
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), `className: "shop-client" is not a valid TypeScript identifier`)
+	assert.Contains(t, err.Error(), `defaultBaseUrl: "/api" is not an absolute http(s) URL`)
+	assert.Contains(t, err.Error(), `output.sdkFile: "retry.ts" is already used by the generated files`)
+	assert.Contains(t, err.Error(), `headers.sdkVersion: "x sdk version" is not a valid header name`)
+	assert.Contains(t, err.Error(), `includeFallback[1]: "" is not a valid include value`)
+	assert.Contains(t, err.Error(), `includeDepth: -1 must not be negative`)
+}
+
+func TestConfiguredGeneration(t *testing.T) {
+	defer func(options GeneratorOptions) { generatorOptions = options }(generatorOptions)
+	generatorOptions.ClassName = "ShopClient"
+	generatorOptions.DefaultBaseURL = "https://api.example.com"
+	generatorOptions.SDKVersionHeader = "x-shop-sdk-version"
+	generatorOptions.TotalCountHeader = "X-Total-Count"
+	generatorOptions.IncludeFallback = []string{"category"}
+	generatorOptions.Pagination = false
+	generatorOptions.WithResponse = false
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/comprehensive_filters.yaml")
+	assert.NoError(t, err)
+
+	paramDefinitions := getParamDefinitions(doc)
+	sdkString := string(generateSDK(doc, getTypeDefinitions(doc), paramDefinitions))
+
+	assert.Contains(t, sdkString, "export interface ShopClientOptions {\n")
+	assert.Contains(t, sdkString, "export class ShopClient {\n")
+	assert.Contains(t, sdkString, "  constructor(baseUrl: string = 'https://api.test.com', options: ShopClientOptions = {}) {\n")
+	assert.Contains(t, sdkString, "'x-shop-sdk-version': SDK_VERSION,\n")
+	assert.NotContains(t, sdkString, "x-gocart-sdk-version")
+	assert.Contains(t, sdkString, "'X-Total-Count': 'include'\n")
+
+	// Disabled features are not generated
+	assert.NotContains(t, sdkString, "from './pagination'")
+	assert.NotContains(t, sdkString, "iterateUsers")
+	assert.NotContains(t, sdkString, "from './response'")
+	assert.NotContains(t, sdkString, "WithResponse(")
+
+	paramsString := string(generateParams(doc, paramDefinitions))
+	assert.Contains(t, paramsString, "IncludeOption = 'category';")
+
+	// The default base URL is used when the document declares no servers
+	doc, err = loader.LoadFromFile("testdata/date_range_input.yaml")
+	assert.NoError(t, err)
+	sdkString = string(generateSDK(doc, getTypeDefinitions(doc), getParamDefinitions(doc)))
+	assert.Contains(t, sdkString, "  constructor(baseUrl: string = 'https://api.example.com', options: ShopClientOptions = {}) {\n")
+}
+
+func TestCheckOutput(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/date_range_input.yaml")
+	assert.NoError(t, err)
+
+	files, err := renderSDK(doc)
+	assert.NoError(t, err)
+	assert.Equal(t, "sdk.ts", files[0].Name)
+	assert.Equal(t, "types.ts", files[1].Name)
+	assert.Equal(t, "params.ts", files[2].Name)
+
+	// Missing files are stale
+	dir := t.TempDir()
+	var diff bytes.Buffer
+	stale, err := checkOutput(dir, files, &diff)
+	assert.NoError(t, err)
+	assert.Len(t, stale, len(files))
+	assert.Contains(t, diff.String(), "--- "+os.DevNull+"\n+++ "+filepath.Join(dir, "sdk.ts")+" (generated)\n")
+
+	// Up to date files pass the check
+	written, err := writeOutput(dir, files)
+	assert.NoError(t, err)
+	assert.Len(t, written, len(files))
+	diff.Reset()
+	stale, err = checkOutput(dir, files, &diff)
+	assert.NoError(t, err)
+	assert.Empty(t, stale)
+	assert.Empty(t, diff.String())
+
+	// Edited files are reported with a unified diff, and left untouched
+	typesPath := filepath.Join(dir, "types.ts")
+	edited := string(files[1].Content) + "// Edited manually\n"
+	assert.NoError(t, os.WriteFile(typesPath, []byte(edited), 0644))
+	stale, err = checkOutput(dir, files, &diff)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"types.ts"}, stale)
+	assert.Contains(t, diff.String(), "--- "+typesPath+"\n+++ "+typesPath+" (generated)\n")
+	assert.Contains(t, diff.String(), "-// Edited manually\n")
+
+	content, err := os.ReadFile(typesPath)
+	assert.NoError(t, err)
+	assert.Equal(t, edited, string(content))
+}
+
+func TestWatchDocument(t *testing.T) {
+	dir := t.TempDir()
+	docPath := filepath.Join(dir, "openapi.yaml")
+	schemasPath := filepath.Join(dir, "schemas.yaml")
+	outputPath := filepath.Join(dir, "src")
+
+	assert.NoError(t, os.WriteFile(docPath, []byte(`
+openapi: 3.0.0
+info:
+  title: Watch API
+  version: 1.0.0
+paths:
+  /products:
+    get:
+      operationId: listProducts
+      responses:
+        '200':
+          description: Products
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Product'
+components:
+  schemas:
+    Product:
+      $ref: './schemas.yaml#/Product'
+`), 0644))
+	assert.NoError(t, os.WriteFile(schemasPath, []byte("Product:\n  type: object\n  properties:\n    name:\n      type: string\n"), 0644))
+
+	// The referenced files are watched along with the document
+	_, files, err := loadDocumentFile(docPath)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{docPath, schemasPath}, files)
+
+	var mu sync.Mutex
+	var logs []string
+	logf := func(format string, args ...any) {
+		mu.Lock()
+		defer mu.Unlock()
+		logs = append(logs, fmt.Sprintf(format, args...))
+	}
+	waitFor := func(condition func() bool) {
+		assert.Eventually(t, condition, 5*time.Second, 10*time.Millisecond)
+	}
+	typesContain := func(s string) func() bool {
+		return func() bool {
+			content, err := os.ReadFile(filepath.Join(outputPath, "types.ts"))
+			return err == nil && strings.Contains(string(content), s)
+		}
+	}
+	logged := func(s string) func() bool {
+		return func() bool {
+			mu.Lock()
+			defer mu.Unlock()
+			for _, line := range logs {
+				if strings.Contains(line, s) {
+					return true
+				}
+			}
+			return false
+		}
+	}
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		watchDocument(docPath, outputPath, 10*time.Millisecond, 20*time.Millisecond, stop, logf)
+		close(done)
+	}()
+	defer func() {
+		close(stop)
+		<-done
+	}()
+
+	waitFor(typesContain("name?: string;"))
+
+	// A change of a referenced file regenerates the affected outputs only
+	assert.NoError(t, os.WriteFile(schemasPath, []byte("Product:\n  type: object\n  properties:\n    title:\n      type: string\n"), 0644))
+	waitFor(typesContain("title?: string;"))
+	waitFor(logged("Regenerated types.ts in "))
+
+	// Load errors are reported without stopping the watch
+	assert.NoError(t, os.WriteFile(schemasPath, []byte("Product: [\n"), 0644))
+	waitFor(logged("Failed to load OpenAPI document"))
+	assert.NoError(t, os.WriteFile(schemasPath, []byte("Product:\n  type: object\n  properties:\n    sku:\n      type: string\n"), 0644))
+	waitFor(typesContain("sku?: string;"))
+}
+
+func TestValidateDocument(t *testing.T) {
+	spec := []byte(`openapi: 3.0.0
+info:
+  title: Validation API
+  version: 1.0.0
+paths:
+  /stores/{store_id}:
+    get:
+      operationId: getStore
+      responses:
+        '200':
+          description: Store
+          content:
+            application/xml:
+              schema:
+                type: object
+    head:
+      operationId: headStore
+      responses:
+        '200':
+          description: Store
+  /stores:
+    post:
+      requestBody:
+        content:
+          text/plain:
+            schema:
+              type: string
+      responses:
+        '400':
+          description: Bad request
+  /shops:
+    get:
+      operationId: getStore
+      responses:
+        '200':
+          description: Shops
+`)
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(spec)
+	assert.NoError(t, err)
+
+	diagnostics := validateDocument(doc, spec, "openapi.yaml")
+	var lines []string
+	for _, diagnostic := range diagnostics {
+		lines = append(lines, diagnostic.String())
+	}
+	assert.Equal(t, []string{
+		"openapi.yaml:7: error: path parameter store_id of /stores/{store_id} is not declared",
+		"openapi.yaml:13: warning: response content type application/xml of GET /stores/{store_id} is not supported; the response is typed as any",
+		"openapi.yaml:16: warning: HEAD /stores/{store_id} is skipped: the HEAD method is not supported",
+		"openapi.yaml:22: warning: POST /stores has no operationId; its method is named postStores",
+		"openapi.yaml:25: warning: request body content type text/plain of POST /stores is not supported and is ignored",
+		"openapi.yaml:28: warning: POST /stores declares no success response; it resolves to any",
+		"openapi.yaml:32: error: GET /shops generates the same method getStore as GET /stores/{store_id}; set x-gocart-sdk-name",
+	}, lines)
+	assert.True(t, diagnostics.Failed(false))
+
+	var logs []string
+	logf := func(format string, args ...any) {
+		logs = append(logs, fmt.Sprintf(format, args...))
+	}
+	assert.False(t, reportDiagnostics(diagnostics, false, logf))
+	assert.Equal(t, "OpenAPI document is invalid: 2 error(s)", logs[len(logs)-1])
+
+	// Warnings only fail the validation in strict mode
+	var warnings Diagnostics
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Severity == SeverityWarning {
+			warnings = append(warnings, diagnostic)
+		}
+	}
+	assert.False(t, warnings.Failed(false))
+	assert.True(t, warnings.Failed(true))
+
+	// The documents of the test data are valid
+	for _, file := range []string{"testdata/comprehensive_filters.yaml", "testdata/date_range_input.yaml", "testdata/test_brand_nullable.yaml"} {
+		data, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		doc, err := openapi3.NewLoader().LoadFromData(data)
+		assert.NoError(t, err)
+		assert.False(t, validateDocument(doc, data, file).Failed(false), file)
+	}
+
+	// Unparsable sources are reported without lines
+	assert.Equal(t, "<stdin>: error: message", Diagnostic{Severity: SeverityError, File: "<stdin>", Message: "message"}.String())
+	assert.Equal(t, 0, newSourceLocator([]byte("{")).line("paths"))
+}
+
+func TestOperationNames(t *testing.T) {
+	assert.Equal(t, "getStoresByStoreIdProducts", fallbackMethodName("GET", "/stores/{store_id}/products"))
+	assert.Equal(t, "postOrderItems", fallbackMethodName("post", "/order-items"))
+	assert.Equal(t, "deleteV2CartsByCartId", fallbackMethodName("DELETE", "/v2/carts/{cartId}"))
+	assert.Equal(t, "getÉtiquettesByÉtiquetteId", fallbackMethodName("GET", "/étiquettes/{étiquette_id}"))
+
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Names API
+  version: 1.0.0
+paths:
+  /stores/{store_id}/products:
+    get:
+      parameters:
+        - name: store_id
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: page
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: Products
+    post:
+      operationId: StoreProducts_Create
+      x-gocart-sdk-name: createStoreProduct
+      parameters:
+        - name: store_id
+          in: path
+          required: true
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+      responses:
+        '201':
+          description: Created
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	// Operations without operationId are generated under their fallback name
+	paramDefinitions := getParamDefinitions(doc)
+	assert.Len(t, paramDefinitions, 1)
+	assert.Equal(t, "GetStoresByStoreIdProductsParams", paramDefinitions[0].Name)
+
+	sdkString := string(generateSDK(doc, []TypeDefinition{}, paramDefinitions))
+	assert.Contains(t, sdkString, "public async getStoresByStoreIdProducts(storeId: string, params: GetStoresByStoreIdProductsParams = {}")
+	assert.NotContains(t, sdkString, "public async (")
+
+	// The extension overrides the operationId, including in the derived type names
+	assert.Contains(t, sdkString, "public async createStoreProduct(storeId: string, req: CreateStoreProductRequest")
+	assert.NotContains(t, sdkString, "StoreProducts_Create")
+	assert.Contains(t, gatherRequestBodies(doc), "createStoreProduct")
+
+	diagnostics := validateDocument(doc, []byte(openAPISpec), "openapi.yaml")
+	assert.False(t, diagnostics.Failed(false))
+	assert.Equal(t, "openapi.yaml:8: warning: GET /stores/{store_id}/products has no operationId; its method is named getStoresByStoreIdProducts", diagnostics[0].String())
+
+	// Names colliding across the document, or invalid in TypeScript, fail the validation
+	doc.Paths.Find("/stores/{store_id}/products").Post.Extensions["x-gocart-sdk-name"] = "getStoresByStoreIdProducts"
+	diagnostics = validateDocument(doc, []byte(openAPISpec), "openapi.yaml")
+	assert.True(t, diagnostics.Failed(false))
+	assert.Equal(t, "openapi.yaml:22: error: POST /stores/{store_id}/products generates the same method getStoresByStoreIdProducts as GET /stores/{store_id}/products; set x-gocart-sdk-name", diagnostics[len(diagnostics)-1].String())
+
+	doc.Paths.Find("/stores/{store_id}/products").Post.Extensions["x-gocart-sdk-name"] = "create-product"
+	diagnostics = validateDocument(doc, []byte(openAPISpec), "openapi.yaml")
+	assert.Equal(t, "openapi.yaml:22: error: method name create-product of POST /stores/{store_id}/products is not a valid TypeScript identifier; set x-gocart-sdk-name", diagnostics[len(diagnostics)-1].String())
+}
+
+func TestHeaderAndCookieParameters(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Headers API
+  version: 1.0.0
+paths:
+  /products:
+    parameters:
+      - name: X-Store-Id
+        in: header
+        required: true
+        description: Store the request applies to
+        schema:
+          type: string
+    get:
+      operationId: listProducts
+      parameters:
+        - name: Accept-Language
+          in: header
+          schema:
+            type: string
+        - name: Authorization
+          in: header
+          schema:
+            type: string
+        - name: session_id
+          in: cookie
+          schema:
+            type: string
+        - name: page[number]
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: Products
+          content:
+            application/json:
+              schema:
+                type: object
+    post:
+      operationId: createProduct
+      parameters:
+        - name: Idempotency-Key
+          in: header
+          schema:
+            type: string
+        - name: X-Store-Id
+          in: header
+          required: false
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+              properties:
+                name:
+                  type: string
+      responses:
+        '201':
+          description: Created
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	// Header and cookie parameters are grouped on the params, path parameters included and
+	// ignored headers left out; non-GET operations get params for them
+	paramDefinitions := getParamDefinitions(doc)
+	assert.Len(t, paramDefinitions, 2)
+	paramsString := string(generateParams(doc, paramDefinitions))
+	assert.Contains(t, paramsString, "  /**\n"+
+		"   * Headers of the request.\n"+
+		"   */\n"+
+		"  headers: {\n"+
+		"    /**\n"+
+		"     * Store the request applies to\n"+
+		"     */\n"+
+		"    xStoreId: string;\n"+
+		"\n"+
+		"    acceptLanguage?: string;\n"+
+		"\n"+
+		"  };\n")
+	assert.Contains(t, paramsString, "  cookies?: {\n    sessionId?: string;\n\n  };\n")
+	assert.Contains(t, paramsString, "export interface CreateProductParams {\n"+
+		"  /**\n"+
+		"   * Headers of the request.\n"+
+		"   */\n"+
+		"  headers?: {\n"+
+		"    xStoreId?: string;\n"+
+		"\n"+
+		"    idempotencyKey?: string;\n"+
+		"\n"+
+		"  };\n")
+	assert.NotContains(t, paramsString, "authorization")
+
+	sdkString := string(generateSDK(doc, []TypeDefinition{}, paramDefinitions))
+
+	// Required headers make the params argument required
+	assert.Contains(t, sdkString, "public async listProducts(params: ListProductsParams, options?: RequestOptions)")
+	assert.Contains(t, sdkString, "public async createProduct(req: CreateProductRequest, params: CreateProductParams = {}, options?: RequestOptions)")
+
+	assert.Contains(t, sdkString, "      const paramHeaders: Record<string, string> = {};\n"+
+		"      if (params.headers) {\n"+
+		"        if (params.headers.xStoreId !== undefined && params.headers.xStoreId !== null) {\n"+
+		"          paramHeaders['X-Store-Id'] = String(params.headers.xStoreId);\n"+
+		"        }\n"+
+		"        if (params.headers.acceptLanguage !== undefined && params.headers.acceptLanguage !== null) {\n"+
+		"          paramHeaders['Accept-Language'] = String(params.headers.acceptLanguage);\n"+
+		"        }\n"+
+		"      }\n"+
+		"      if (params.cookies) {\n"+
+		"        const cookies: string[] = [];\n"+
+		"        if (params.cookies.sessionId !== undefined && params.cookies.sessionId !== null) {\n"+
+		"          cookies.push('session_id=' + encodeURIComponent(String(params.cookies.sessionId)));\n"+
+		"        }\n"+
+		"        if (cookies.length > 0) {\n"+
+		"          paramHeaders['Cookie'] = cookies.join('; ');\n"+
+		"        }\n"+
+		"      }\n")
+	assert.Contains(t, sdkString, "          ...paramHeaders,\n          ...callOptions.headers,\n")
+	assert.Contains(t, sdkString, "paramHeaders['Idempotency-Key'] = String(params.headers.idempotencyKey);\n")
+	assert.NotContains(t, sdkString, "paramHeaders['Authorization']")
+}
+
+func TestQueryParameters(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Query API
+  version: 1.0.0
+paths:
+  /products:
+    get:
+      operationId: listProducts
+      parameters:
+        - name: q
+          in: query
+          required: true
+          description: Full-text search
+          schema:
+            type: string
+        - name: currency
+          in: query
+          schema:
+            type: string
+            enum: [USD, EUR]
+        - name: fields[product]
+          in: query
+          schema:
+            type: string
+        - name: page[number]
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: Products
+          content:
+            application/json:
+              schema:
+                type: object
+  /products/{product_id}:
+    delete:
+      operationId: deleteProduct
+      parameters:
+        - name: product_id
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: force
+          in: query
+          schema:
+            type: boolean
+      responses:
+        '204':
+          description: Deleted
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	// Plain parameters are typed as they are, bracketed ones grouped
+	paramDefinitions := getParamDefinitions(doc)
+	assert.Len(t, paramDefinitions, 2)
+	paramsString := string(generateParams(doc, paramDefinitions))
+	assert.Contains(t, paramsString, "  /**\n   * Full-text search\n   */\n  q: string;\n")
+	assert.Contains(t, paramsString, "  fields?: {\n    product?: string;\n\n  };\n")
+	assert.Contains(t, paramsString, "export interface DeleteProductParams {\n"+
+		"  /**\n"+
+		"   * Force for the API.\n"+
+		"   */\n"+
+		"  force?: boolean;\n")
+
+	sdkString := string(generateSDK(doc, []TypeDefinition{}, paramDefinitions))
+
+	// Every query parameter is sent
+	assert.Contains(t, sdkString, "      if (params.q !== undefined && params.q !== null) {\n"+
+		"        queryString.append('q', String(params.q));\n"+
+		"      }\n"+
+		"      if (params.currency !== undefined && params.currency !== null) {\n"+
+		"        queryString.append('currency', String(params.currency));\n"+
+		"      }\n"+
+		"      if (params.fields) {\n"+
+		"        if (params.fields.product !== undefined && params.fields.product !== null) {\n"+
+		"          queryString.append('fields[product]', String(params.fields.product));\n"+
+		"        }\n"+
+		"      }\n")
+
+	// A required plain parameter makes params required
+	assert.Contains(t, sdkString, "public async listProducts(params: ListProductsParams, options?: RequestOptions)")
+
+	// Operations other than GET take params for their query parameters
+	assert.Contains(t, sdkString, "public async deleteProduct(productId: string, params: DeleteProductParams = {}, options?: RequestOptions)")
+	assert.Contains(t, sdkString, "        queryString.append('force', String(params.force));\n")
+}
+
+func TestParameterSerialization(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Styles API
+  version: 1.0.0
+paths:
+  /products/{ids}:
+    get:
+      operationId: listProducts
+      parameters:
+        - name: ids
+          in: path
+          required: true
+          style: label
+          explode: true
+          schema:
+            type: array
+            items:
+              type: string
+        - name: filter[status]
+          in: query
+          explode: false
+          schema:
+            type: array
+            items:
+              type: string
+        - name: tags
+          in: query
+          style: pipeDelimited
+          explode: false
+          schema:
+            type: array
+            items:
+              type: string
+        - name: metadata
+          in: query
+          style: deepObject
+          schema:
+            type: object
+            additionalProperties:
+              type: string
+        - name: redirect
+          in: query
+          allowReserved: true
+          schema:
+            type: string
+        - name: X-Roles
+          in: header
+          schema:
+            type: array
+            items:
+              type: string
+        - name: prefs
+          in: cookie
+          explode: false
+          schema:
+            type: array
+            items:
+              type: string
+      responses:
+        '200':
+          description: Products
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	paramDefinitions := getParamDefinitions(doc)
+	sdkString := string(generateSDK(doc, []TypeDefinition{}, paramDefinitions))
+
+	assert.Contains(t, sdkString, "import { QueryString, appendQueryParam, serializeCookieParam, serializeHeaderParam, serializePathParam } from './serialize';\n")
+
+	// Path parameters follow their style
+	assert.Contains(t, sdkString, "const url = `${this.baseUrl}/products/${serializePathParam('ids', ids, 'label', true)}`;")
+
+	// Structured query parameters follow their style and explode settings
+	assert.Contains(t, sdkString, "appendQueryParam(queryString, 'filter[status]', formatted, 'form', false, false);")
+	assert.Contains(t, sdkString, "appendQueryParam(queryString, 'tags', params.tags, 'pipeDelimited', false, false);")
+	assert.Contains(t, sdkString, "appendQueryParam(queryString, 'metadata', params.metadata, 'deepObject', true, false);")
+
+	// Reserved characters are kept with allowReserved
+	assert.Contains(t, sdkString, "queryString.append('redirect', String(params.redirect), true);")
+
+	// Header and cookie parameters
+	assert.Contains(t, sdkString, "paramHeaders['X-Roles'] = serializeHeaderParam(params.headers.xRoles, false);")
+	assert.Contains(t, sdkString, "cookies.push(serializeCookieParam('prefs', params.cookies.prefs, false));")
+
+	// Exploded cookie values are separate cookies
+	runtimeFiles, err := getRuntimeFiles()
+	assert.NoError(t, err)
+	for _, f := range runtimeFiles {
+		if f.Name == "serialize.ts" {
+			assert.Contains(t, string(f.Content), "return explode ? items.map((item) => `${name}=${item}`).join('; ') : `${name}=${items.join(',')}`;")
+			assert.Contains(t, string(f.Content), "return explode ? entries.map(([k, v]) => `${k}=${v}`).join('; ') : `${name}=${entries.flat().join(',')}`;")
+		}
+	}
+}
+
+func TestSparseFieldsets(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Fields API
+  version: 1.0.0
+paths:
+  /products:
+    get:
+      operationId: listProducts
+      parameters:
+        - name: fields[products]
+          in: query
+          description: Fields of the products
+          schema:
+            type: string
+        - name: fields[store]
+          in: query
+          schema:
+            type: string
+        - name: fields[unknown]
+          in: query
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Products
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/ProductList'
+  /products/{product_id}:
+    get:
+      operationId: getProduct
+      parameters:
+        - name: product_id
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: fields[product]
+          in: query
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Product
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Product'
+components:
+  schemas:
+    Product:
+      type: object
+      required: [id]
+      properties:
+        id:
+          type: string
+        name:
+          type: string
+        unit_price:
+          type: number
+        line_2:
+          type: string
+        _embedded:
+          type: object
+          properties:
+            store:
+              $ref: '#/components/schemas/Store'
+    Store:
+      type: object
+      properties:
+        id:
+          type: string
+        name:
+          type: string
+    ProductList:
+      type: object
+      properties:
+        data:
+          type: array
+          items:
+            $ref: '#/components/schemas/Product'
+        meta:
+          type: object
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	// Fields are unions of the property names of the resources, _embedded ones included
+	paramDefinitions := getParamDefinitions(doc)
+	paramsString := string(generateParams(doc, paramDefinitions))
+	assert.Contains(t, paramsString, "export type ProductField = 'id' | 'line2' | 'name' | 'store' | 'unitPrice';\n")
+	assert.Contains(t, paramsString, "export type StoreField = 'id' | 'name';\n")
+
+	// Field names map to the property names of the resources
+	assert.Contains(t, paramsString, "export const productFieldNames: Record<ProductField, string> = { id: 'id', line2: 'line_2', name: 'name', store: 'store', unitPrice: 'unit_price' };\n")
+	assert.Contains(t, paramsString, "export const storeFieldNames: Record<StoreField, string> = { id: 'id', name: 'name' };\n")
+	assert.Equal(t, 1, strings.Count(paramsString, "export type ProductField"))
+	assert.Contains(t, paramsString, "export interface ListProductsParams<F extends ProductField = ProductField> {\n")
+	assert.Contains(t, paramsString, "    products?: F[];\n\n    store?: StoreField[];\n\n    unknown?: string;\n")
+	assert.Contains(t, paramsString, "export interface GetProductParams<F extends ProductField = ProductField> {\n")
+
+	sdkString := string(generateSDK(doc, getTypeDefinitions(doc), paramDefinitions))
+	assert.Contains(t, sdkString, "  ProductField,\n")
+	assert.Contains(t, sdkString, "  Product,\n")
+
+	// The response is narrowed to the requested fields
+	assert.Contains(t, sdkString, "public getProduct<F extends ProductField = ProductField>(productId: string, params?: GetProductParams<F>, options?: RequestOptions): Promise<Pick<Product, F>>;")
+	assert.Contains(t, sdkString, "public listProducts<F extends ProductField = ProductField>(params?: ListProductsParams<F>, options?: RequestOptions): Promise<Omit<ProductList, 'data'> & { data?: Pick<Product, F>[] }>;")
+
+	// Field names are sent as comma-separated property names, unknown resources as they are
+	assert.Contains(t, sdkString, "  productFieldNames,\n  storeFieldNames,\n")
+	assert.Contains(t, sdkString, "queryString.append('fields[products]', params.fields.products.map((v) => productFieldNames[v]).join(','));")
+	assert.Contains(t, sdkString, "queryString.append('fields[store]', params.fields.store.map((v) => storeFieldNames[v]).join(','));")
+	assert.Contains(t, sdkString, "queryString.append('fields[unknown]', String(params.fields.unknown));")
+}
+
+func TestIncludeOptions(t *testing.T) {
+	defer func(options GeneratorOptions) { generatorOptions = options }(generatorOptions)
+
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Orders API
+  version: 1.0.0
+paths:
+  /orders:
+    get:
+      operationId: listOrders
+      parameters:
+        - name: include
+          in: query
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Orders
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  data:
+                    type: array
+                    items:
+                      $ref: '#/components/schemas/Order'
+  /orders/{order_id}:
+    get:
+      operationId: getOrder
+      parameters:
+        - name: order_id
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: include
+          in: query
+          schema:
+            type: string
+        - name: fields[order]
+          in: query
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Order
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Order'
+  /categories:
+    get:
+      operationId: listCategories
+      parameters:
+        - name: include
+          in: query
+          x-gocart-includes: [parent, sub_categories]
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Categories
+          content:
+            application/json:
+              schema:
+                type: object
+components:
+  schemas:
+    Order:
+      type: object
+      properties:
+        id:
+          type: string
+        _embedded:
+          type: object
+          properties:
+            customer:
+              $ref: '#/components/schemas/Customer'
+            line_items:
+              type: array
+              items:
+                $ref: '#/components/schemas/LineItem'
+    LineItem:
+      type: object
+      properties:
+        id:
+          type: string
+        _embedded:
+          type: object
+          properties:
+            product:
+              $ref: '#/components/schemas/Product'
+    Product:
+      type: object
+      properties:
+        id:
+          type: string
+        _embedded:
+          type: object
+          properties:
+            line_items:
+              type: array
+              items:
+                $ref: '#/components/schemas/LineItem'
+    Customer:
+      type: object
+      properties:
+        id:
+          type: string
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+	assert.Empty(t, validateDocument(doc, []byte(openAPISpec), "openapi.yaml"))
+
+	// Include values are derived from the embedded relationships, recursively, or declared
+	paramDefinitions := getParamDefinitions(doc)
+	paramsString := string(generateParams(doc, paramDefinitions))
+	assert.Contains(t, paramsString, "export type ListOrdersParamsIncludeOption = 'customer' | 'lineItems' | 'lineItems.product' | 'lineItems.product.lineItems';\n")
+	assert.Contains(t, paramsString, "export type ListCategoriesParamsIncludeOption = 'parent' | 'subCategories';\n")
+	assert.Contains(t, paramsString, "  include?: GetOrderParamsIncludeOption[];\n")
+
+	// The requested relationships are marked as present in the resource
+	sdkString := string(generateSDK(doc, getTypeDefinitions(doc), paramDefinitions))
+	assert.Contains(t, sdkString, "import { Included } from './include';\n")
+	assert.Contains(t, sdkString, "  GetOrderParamsIncludeOption,\n")
+	assert.Contains(t, sdkString, "public getOrder<F extends OrderField = OrderField, I extends GetOrderParamsIncludeOption = never>(orderId: string, params?: GetOrderParams<F> & { include?: I[] }, options?: RequestOptions): Promise<Included<Pick<Order, F>, I>>;")
+	assert.Contains(t, sdkString, "public listOrders<I extends ListOrdersParamsIncludeOption = never>(params?: ListOrdersParams & { include?: I[] }, options?: RequestOptions): Promise<Omit<any, 'data'> & { data?: Included<Order, I>[] }>;")
+
+	// Responses without a resource type are not narrowed
+	assert.Contains(t, sdkString, "public listCategories(params?: ListCategoriesParams, options?: RequestOptions): Promise<any>;")
+
+	// The depth of the derived values is configurable, the fallback being used when none are derived
+	generatorOptions.IncludeDepth = 1
+	generatorOptions.IncludeFallback = []string{"category"}
+	paramsString = string(generateParams(doc, getParamDefinitions(doc)))
+	assert.Contains(t, paramsString, "export type ListOrdersParamsIncludeOption = 'customer' | 'lineItems';\n")
+	doc.Components.Schemas["Order"].Value.Properties["_embedded"].Value.Properties = nil
+	paramsString = string(generateParams(doc, getParamDefinitions(doc)))
+	assert.Contains(t, paramsString, "export type ListOrdersParamsIncludeOption = 'category';\n")
+
+	// Extensions must list include values
+	doc.Paths.Find("/categories").Get.Parameters[0].Value.Extensions["x-gocart-includes"] = []any{"parent", "sub categories"}
+	diagnostics := validateDocument(doc, []byte(openAPISpec), "openapi.yaml")
+	if assert.Len(t, diagnostics, 1) {
+		assert.Equal(t, SeverityError, diagnostics[0].Severity)
+		assert.Contains(t, diagnostics[0].Message, `x-gocart-includes of GET /categories: "sub categories" is not a valid include value`)
+	}
+}
+
+func TestGroupByTagResourceNameCollisions(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /context:
+    get:
+      operationId: getContext
+      tags: [Context]
+      responses:
+        '200':
+          description: Success
+  /order-items:
+    get:
+      operationId: listOrderItems
+      tags: [Order Items]
+      responses:
+        '200':
+          description: Success
+  /legacy/order-items:
+    get:
+      operationId: listLegacyOrderItems
+      tags: [order_items]
+      responses:
+        '200':
+          description: Success
+  /health:
+    get:
+      operationId: healthCheck
+      responses:
+        '200':
+          description: Success
+  /status:
+    get:
+      operationId: getStatus
+      tags: [healthCheck]
+      responses:
+        '200':
+          description: Success
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	defer func(options GeneratorOptions) { generatorOptions = options }(generatorOptions)
+	generatorOptions.GroupByTag = true
+
+	sdkString := string(generateSDK(doc, getTypeDefinitions(doc), getParamDefinitions(doc)))
+
+	// Resources named after members of the SDK class keep the members intact
+	assert.Contains(t, sdkString, "  public context: InMemoryContext;\n")
+	assert.Contains(t, sdkString, "  public readonly contextApi: ContextApi;\n")
+	assert.Contains(t, sdkString, "    this.contextApi = new ContextApi(client);\n")
+	assert.NotContains(t, sdkString, "public readonly context:")
+	assert.Contains(t, sdkString, "  public readonly healthCheck2: HealthCheck2Api;\n")
+	assert.Contains(t, sdkString, "  public async healthCheck(")
+
+	// Tags normalizing to the same name get distinct sub-clients
+	assert.Contains(t, sdkString, "  public readonly orderItems: OrderItemsApi;\n")
+	assert.Contains(t, sdkString, "  public readonly orderItems2: OrderItems2Api;\n")
+	assert.Equal(t, 1, strings.Count(sdkString, "export class OrderItemsApi extends ApiResource {\n"))
+	assert.Equal(t, 1, strings.Count(sdkString, "export class OrderItems2Api extends ApiResource {\n"))
+}
+
+func TestErrorSchemaDoesNotShadowGlobalError(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+components:
+  schemas:
+    Error:
+      type: object
+      properties:
+        message:
+          type: string
+paths:
+  /products:
+    get:
+      operationId: listProducts
+      responses:
+        '200':
+          description: Success
+        '404':
+          description: Not found
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Error'
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	sdkString := string(generateSDK(doc, getTypeDefinitions(doc), getParamDefinitions(doc)))
+
+	// The schema is imported by its bare name, so the generated code throws the global Error
+	assert.Contains(t, sdkString, "import {\n  Error,\n} from './types';\n")
+	assert.Contains(t, sdkString, "ApiError<404, Error>")
+	assert.Contains(t, sdkString, "throw new globalThis.Error(`Request to ${url}")
+	assert.NotContains(t, sdkString, "new Error(")
+}
+
+func TestListParamsFollowTheirStyle(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /orders:
+    get:
+      operationId: listOrders
+      parameters:
+        - name: include
+          in: query
+          explode: true
+          schema:
+            type: array
+            items:
+              type: string
+              enum: [lineItems, lineItems.productVariant, customer]
+        - name: sort
+          in: query
+          style: pipeDelimited
+          explode: false
+          schema:
+            type: array
+            items:
+              type: string
+        - name: page[number]
+          in: query
+          schema:
+            type: integer
+      responses:
+        '200':
+          description: Success
+  /products:
+    get:
+      operationId: listProducts
+      parameters:
+        - name: include
+          in: query
+          schema:
+            type: string
+        - name: sort
+          in: query
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Success
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	sdkString := string(generateSDK(doc, getTypeDefinitions(doc), getParamDefinitions(doc)))
+	assert.Contains(t, sdkString, "import { QueryString, appendQueryParam } from './serialize';\n")
+
+	// Array parameters are serialized according to their style, an exploded include repeating the parameter
+	listOrders := sdkString[strings.Index(sdkString, "public async listOrders("):]
+	listOrders = listOrders[:strings.Index(listOrders, "\n  }\n")]
+	assert.Contains(t, listOrders, "appendQueryParam(queryString, 'include', params.include.map((v) => v.split('.').map((segment) => segment.replace(/([a-z])([A-Z])/g, '$1_$2').toLowerCase()).join('.')), 'form', true, false);\n")
+	assert.Contains(t, listOrders, "appendQueryParam(queryString, 'sort', params.sort.map((v) => v.replace(/([A-Z])/g, '_$1').toLowerCase()), 'pipeDelimited', false, false);\n")
+	assert.Contains(t, listOrders, "queryString.append('page[number]', String(params.page.number));\n")
+
+	// String parameters get a comma-separated list
+	listProducts := sdkString[strings.Index(sdkString, "public async listProducts("):]
+	listProducts = listProducts[:strings.Index(listProducts, "\n  }\n")]
+	assert.Contains(t, listProducts, "queryString.append('include', params.include.map((v) => v.split('.').map((segment) => segment.replace(/([a-z])([A-Z])/g, '$1_$2').toLowerCase()).join('.')).join(','));\n")
+	assert.Contains(t, listProducts, "queryString.append('sort', params.sort.map((v) => v.replace(/([A-Z])/g, '_$1').toLowerCase()).join(','));\n")
+}
+
+func TestGroupByTagNestedRouteMethodNames(t *testing.T) {
+	openAPISpec := `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: 1.0.0
+paths:
+  /carts/{cart_id}/items/{item_id}:
+    delete:
+      tags: [Carts]
+      parameters:
+        - name: cart_id
+          in: path
+          required: true
+          schema:
+            type: string
+        - name: item_id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '204':
+          description: Deleted
+  /carts/{cart_id}/cart-items:
+    get:
+      operationId: listCartItems
+      tags: [Carts]
+      parameters:
+        - name: cart_id
+          in: path
+          required: true
+          schema:
+            type: string
+      responses:
+        '200':
+          description: Success
+`
+
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData([]byte(openAPISpec))
+	assert.NoError(t, err)
+
+	defer func(options GeneratorOptions) { generatorOptions = options }(generatorOptions)
+	generatorOptions.GroupByTag = true
+
+	sdkString := string(generateSDK(doc, getTypeDefinitions(doc), getParamDefinitions(doc)))
+
+	// Only the resource words after the verb are dropped, the path segments are kept
+	assert.Contains(t, sdkString, "  public async deleteByCartIdItemsByItemId(cartId: string, itemId: string")
+	assert.Contains(t, sdkString, "  public async listItems(cartId: string")
+
+	assert.Equal(t, "getByIdRefunds", resourceMethodName("getPaymentsByIdRefunds", "Payments"))
+	assert.Equal(t, "listProductsVariants", resourceMethodName("listProductsVariants", "Variants"))
+	assert.Equal(t, "list", resourceMethodName("listOrderItems", "Order Items"))
+}
+
+func TestRuntimeFilesMatchSDKImports(t *testing.T) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromFile("testdata/comprehensive_filters.yaml")
+	assert.NoError(t, err)
+
+	typeDefinitions := getTypeDefinitions(doc)
+	paramDefinitions := getParamDefinitions(doc)
+	sdkString := string(generateSDK(doc, typeDefinitions, paramDefinitions))
+
+	runtimeFiles, err := getRuntimeFiles()
+	assert.NoError(t, err)
+
+	runtimeModules := map[string]string{}
+	for _, f := range runtimeFiles {
+		runtimeModules["./"+strings.TrimSuffix(f.Name, ".ts")] = string(f.Content)
+	}
+
+	// Every runtime module imported by sdk.ts must be emitted and export the imported names
+	importRe := regexp.MustCompile(`import \{([^}]*)\} from '(\./[^']+)';`)
+	for _, match := range importRe.FindAllStringSubmatch(sdkString, -1) {
+		module := match[2]
+		if module == "./types" || module == "./params" {
+			continue
+		}
+		content, ok := runtimeModules[module]
+		if !assert.True(t, ok, "runtime module %s should be emitted", module) {
+			continue
+		}
+		for _, name := range strings.Split(match[1], ",") {
+			name = strings.TrimSpace(name)
+			if name == "" {
+				continue
+			}
+			exportRe := regexp.MustCompile(`export (class|function|async function\*?|interface|type|const) ` + name + `\b`)
+			assert.Regexp(t, exportRe, content, "%s should export %s", module, name)
+		}
+	}
+}
+
 func max(a, b int) int {
 	if a > b {
 		return a
@@ -0,0 +1,156 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// rangeSDKTypes are the x-gocart-sdk-type values of filters sent as ranges, e.g. filter[price][gte]
+var rangeSDKTypes = []string{"DateRange", "NumberRange", "CurrencyRange"}
+
+// parameterSerialization returns the style and explode settings of a parameter, defaulting to
+// form style for query and cookie parameters and to simple style for path and header ones
+func parameterSerialization(param *openapi3.Parameter) (string, bool) {
+	method, err := param.SerializationMethod()
+	if err != nil {
+		return "", false
+	}
+	return method.Style, method.Explode
+}
+
+// newQueryParameter creates the definition of a parameter of an operation
+func newQueryParameter(param *openapi3.Parameter, sdkType string) QueryParameter {
+	style, explode := parameterSerialization(param)
+	return QueryParameter{
+		Name:          param.Name,
+		In:            param.In,
+		Description:   param.Description,
+		Schema:        param.Schema,
+		Required:      param.Required,
+		SDKType:       sdkType,
+		Style:         style,
+		Explode:       explode,
+		AllowReserved: param.AllowReserved,
+	}
+}
+
+// isStructuredSchema reports whether a parameter schema is an array or an object, whose
+// serialization depends on the style of the parameter
+func isStructuredSchema(schemaRef *openapi3.SchemaRef) bool {
+	if schemaRef == nil || schemaRef.Value == nil {
+		return false
+	}
+	schema := schemaRef.Value
+	return schema.Type.Is("array") || schema.Type.Is("object") || len(schema.Properties) > 0
+}
+
+// usesQuerySerializer reports whether a query parameter is serialized by appendQueryParam.
+// Scalars are appended as they are in every style, and range filters have their own serialization.
+func usesQuerySerializer(qp QueryParameter) bool {
+	if contains(rangeSDKTypes, qp.SDKType) {
+		return false
+	}
+	return isStructuredSchema(qp.Schema)
+}
+
+// queryAppendStatement generates the statement appending a query parameter value to the query string
+func queryAppendStatement(qp QueryParameter, name, value string) string {
+	if usesQuerySerializer(qp) {
+		return fmt.Sprintf("appendQueryParam(queryString, %s, %s, %s, %t, %t);", quoteLiteral(name), value, quoteLiteral(qp.Style), qp.Explode, qp.AllowReserved)
+	}
+	if qp.AllowReserved {
+		return fmt.Sprintf("queryString.append(%s, String(%s), true);", quoteLiteral(name), value)
+	}
+	return fmt.Sprintf("queryString.append(%s, String(%s));", quoteLiteral(name), value)
+}
+
+// listAppendStatement generates the statement appending the values of a list parameter (sort,
+// include or sparse fieldset) to the query string: array schemas are serialized according to
+// their style, string schemas get a comma-separated list
+func listAppendStatement(qp QueryParameter, values string) string {
+	if usesQuerySerializer(qp) {
+		return queryAppendStatement(qp, qp.Name, values)
+	}
+	if qp.AllowReserved {
+		return fmt.Sprintf("queryString.append(%s, %s.join(','), true);", quoteLiteral(qp.Name), values)
+	}
+	return fmt.Sprintf("queryString.append(%s, %s.join(','));", quoteLiteral(qp.Name), values)
+}
+
+// usesPathSerializer reports whether a path parameter is serialized by serializePathParam:
+// arrays and objects, and the label and matrix styles prefixing values
+func usesPathSerializer(param *openapi3.Parameter) bool {
+	if param == nil {
+		return false
+	}
+	style, _ := parameterSerialization(param)
+	return style != openapi3.SerializationSimple || isStructuredSchema(param.Schema)
+}
+
+// pathParamExpression generates the expression of a path parameter in the URL template
+func pathParamExpression(arg MethodArgumentDefinition) string {
+	if !usesPathSerializer(arg.PathParameter) {
+		return arg.Name
+	}
+	style, explode := parameterSerialization(arg.PathParameter)
+	return fmt.Sprintf("serializePathParam(%s, %s, %s, %t)", quoteLiteral(arg.PathParam), arg.Name, quoteLiteral(style), explode)
+}
+
+// headerParamExpression generates the expression of the value of a header parameter
+func headerParamExpression(p QueryParameter, value string) string {
+	if isStructuredSchema(p.Schema) {
+		return fmt.Sprintf("serializeHeaderParam(%s, %t)", value, p.Explode)
+	}
+	return fmt.Sprintf("String(%s)", value)
+}
+
+// cookieParamExpression generates the expression of the name=value pair of a cookie parameter
+func cookieParamExpression(p QueryParameter, value string) string {
+	if isStructuredSchema(p.Schema) {
+		return fmt.Sprintf("serializeCookieParam(%s, %s, %t)", quoteLiteral(p.Name), value, p.Explode)
+	}
+	return fmt.Sprintf("%s + encodeURIComponent(String(%s))", quoteLiteral(p.Name+"="), value)
+}
+
+// serializeImports returns the names imported from the serialize runtime module by the methods
+func serializeImports(methodDefinitions MethodDefinitions) []string {
+	var imports []string
+	add := func(name string) {
+		if !contains(imports, name) {
+			imports = append(imports, name)
+		}
+	}
+
+	for _, m := range methodDefinitions {
+		if m.Arguments.HasParam("params") && m.QueryParams["query"] != nil {
+			add("QueryString")
+			for _, qp := range m.QueryParams["query"] {
+				if usesQuerySerializer(qp) {
+					add("appendQueryParam")
+				}
+			}
+		}
+		for _, arg := range m.Arguments {
+			if usesPathSerializer(arg.PathParameter) {
+				add("serializePathParam")
+			}
+		}
+		if m.Arguments.HasParam("params") {
+			for _, p := range m.QueryParams[openapi3.ParameterInHeader] {
+				if isStructuredSchema(p.Schema) {
+					add("serializeHeaderParam")
+				}
+			}
+			for _, p := range m.QueryParams[openapi3.ParameterInCookie] {
+				if isStructuredSchema(p.Schema) {
+					add("serializeCookieParam")
+				}
+			}
+		}
+	}
+
+	sort.Strings(imports)
+	return imports
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// ServerVariableDefinition represents a variable of the server URLs the SDK can be configured with
+type ServerVariableDefinition struct {
+	Name     string
+	Variable *openapi3.ServerVariable
+}
+
+// getDefaultServer returns the first server of the document, or nil if it declares none
+func getDefaultServer(doc *openapi3.T) *openapi3.Server {
+	if len(doc.Servers) == 0 || doc.Servers[0] == nil {
+		return nil
+	}
+	return doc.Servers[0]
+}
+
+// getOperationServer returns the server overriding the document servers for an operation:
+// the first server of the operation, or else of its path
+func getOperationServer(pathItem *openapi3.PathItem, operation *openapi3.Operation) *openapi3.Server {
+	if operation.Servers != nil && len(*operation.Servers) > 0 {
+		return (*operation.Servers)[0]
+	}
+	if len(pathItem.Servers) > 0 {
+		return pathItem.Servers[0]
+	}
+	return nil
+}
+
+// getServerVariables returns the variables of the default server and the servers overriding it,
+// sorted by name. A variable declared by several servers is typed from the first declaration.
+func getServerVariables(doc *openapi3.T, methodDefinitions MethodDefinitions) []ServerVariableDefinition {
+	servers := []*openapi3.Server{getDefaultServer(doc)}
+	for _, m := range methodDefinitions {
+		servers = append(servers, m.Server)
+	}
+
+	seen := make(map[string]bool)
+	var variables []ServerVariableDefinition
+	for _, server := range servers {
+		if server == nil {
+			continue
+		}
+		for _, name := range sortedServerVariableNames(server) {
+			if seen[name] || server.Variables[name] == nil {
+				continue
+			}
+			seen[name] = true
+			variables = append(variables, ServerVariableDefinition{Name: name, Variable: server.Variables[name]})
+		}
+	}
+
+	sort.Slice(variables, func(i, j int) bool {
+		return variables[i].Name < variables[j].Name
+	})
+
+	return variables
+}
+
+// sortedServerVariableNames returns the names of the variables of a server in alphabetical order
+func sortedServerVariableNames(server *openapi3.Server) []string {
+	names := make([]string, 0, len(server.Variables))
+	for name := range server.Variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// serverVariableType returns the TypeScript type of a server variable: a union of its enum values, or string
+func serverVariableType(variable *openapi3.ServerVariable) string {
+	if len(variable.Enum) == 0 {
+		return "string"
+	}
+	values := make([]string, 0, len(variable.Enum))
+	for _, value := range variable.Enum {
+		values = append(values, quoteLiteral(value))
+	}
+	return strings.Join(values, " | ")
+}
+
+// serverDefaultsLiteral renders the default values of the server variables as an object literal
+func serverDefaultsLiteral(server *openapi3.Server) string {
+	var fields []string
+	for _, name := range sortedServerVariableNames(server) {
+		if server.Variables[name] == nil {
+			continue
+		}
+		fields = append(fields, fmt.Sprintf("%s: %s", propertyKey(name), quoteLiteral(server.Variables[name].Default)))
+	}
+	if len(fields) == 0 {
+		return "{}"
+	}
+	return "{ " + strings.Join(fields, ", ") + " }"
+}
+
+// serverURLExpression returns the TypeScript expression of a server URL, substituting its
+// variables with the values configured on the SDK
+func serverURLExpression(server *openapi3.Server) string {
+	if len(server.Variables) == 0 {
+		return quoteLiteral(strings.TrimRight(server.URL, "/"))
+	}
+	return fmt.Sprintf("resolveServerUrl(%s, %s, this.serverVariables)", quoteLiteral(server.URL), serverDefaultsLiteral(server))
+}
+
+// isRelativeServerURL reports whether a server URL is relative to the document, e.g. /v2
+func isRelativeServerURL(url string) bool {
+	return !strings.Contains(url, "://")
+}
+
+// operationServerURLExpression returns the TypeScript expression of the server URL overriding
+// the document servers for an operation. Relative URLs are resolved against the base URL of the
+// SDK, e.g. /v2 against https://api.example.com/v1 gives https://api.example.com/v2.
+func operationServerURLExpression(server *openapi3.Server) string {
+	if !isRelativeServerURL(server.URL) {
+		return serverURLExpression(server)
+	}
+	// The URL is kept as is, so that / resolves to the origin of the base URL
+	url := quoteLiteral(server.URL)
+	if len(server.Variables) > 0 {
+		url = serverURLExpression(server)
+	}
+	return fmt.Sprintf("resolveRelativeUrl(%s, this.baseUrl)", url)
+}
+
+// serversImports returns the helpers of the servers runtime module used by the SDK
+func serversImports(methodDefinitions MethodDefinitions, hasServerVariables bool) []string {
+	var imports []string
+	for _, m := range methodDefinitions {
+		if m.Server != nil && isRelativeServerURL(m.Server.URL) {
+			imports = append(imports, "resolveRelativeUrl")
+			break
+		}
+	}
+	if hasServerVariables {
+		imports = append(imports, "resolveServerUrl")
+	}
+	return imports
+}
+
+// generateServerTypes generates the options type of the server variables
+func generateServerTypes(variables []ServerVariableDefinition) string {
+	var buf bytes.Buffer
+	buf.WriteString("/**\n")
+	buf.WriteString(" * Variables of the server URLs, e.g. the region or environment of the API\n")
+	buf.WriteString(" */\n")
+	buf.WriteString(fmt.Sprintf("export interface %s {\n", sdkTypeName("ServerVariables")))
+	for i, v := range variables {
+		if i > 0 {
+			buf.WriteString("\n")
+		}
+		var docLines []string
+		if v.Variable.Description != "" {
+			docLines = append(docLines, strings.Split(strings.TrimSpace(v.Variable.Description), "\n")...)
+		}
+		docLines = append(docLines, fmt.Sprintf("@default %s", docValue(v.Variable.Default)))
+		buf.WriteString(renderJSDoc(docLines, "  "))
+		buf.WriteString(fmt.Sprintf("  %s?: %s;\n", propertyKey(v.Name), serverVariableType(v.Variable)))
+	}
+	buf.WriteString("}\n\n")
+	return buf.String()
+}
+
+// generateSDKOptions generates the options type of the SDK constructor
+func generateSDKOptions(hasAuth, hasServerVariables bool) string {
+	var buf bytes.Buffer
+	buf.WriteString("/**\n")
+	buf.WriteString(fmt.Sprintf(" * Options of the %s constructor\n", generatorOptions.ClassName))
+	buf.WriteString(" */\n")
+	buf.WriteString(fmt.Sprintf("export interface %s {\n", sdkTypeName("Options")))
+	if hasAuth {
+		buf.WriteString(fmt.Sprintf("  auth?: %s;\n", sdkTypeName("Auth")))
+	}
+	if hasServerVariables {
+		buf.WriteString(fmt.Sprintf("  serverVariables?: %s;\n", sdkTypeName("ServerVariables")))
+	}
+	if hasAuth || hasServerVariables {
+		buf.WriteString("\n")
+	}
+	buf.WriteString("  /**\n")
+	buf.WriteString("   * Request options applied to every call, e.g. a timeout or extra headers\n")
+	buf.WriteString("   */\n")
+	buf.WriteString("  defaults?: DefaultRequestOptions;\n\n")
+	buf.WriteString("  /**\n")
+	buf.WriteString("   * Retry policy of transient failures, or false to disable retries\n")
+	buf.WriteString("   */\n")
+	buf.WriteString("  retry?: RetryPolicy | false;\n")
+	buf.WriteString("}\n\n")
+	return buf.String()
+}
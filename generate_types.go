@@ -2,8 +2,10 @@ package main
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
@@ -35,9 +37,25 @@ func getTypeDefinitions(doc *openapi3.T) []TypeDefinition {
 
 	// generate types for request bodies
 	requestBodies := gatherRequestBodies(doc)
-	for operationID, schemaRef := range requestBodies {
+	var operationIDs []string
+	for operationID := range requestBodies {
+		operationIDs = append(operationIDs, operationID)
+	}
+	sort.Strings(operationIDs)
+	for _, operationID := range operationIDs {
 		operationName := fmt.Sprintf("%sRequest", operationID)
-		typeDefs = append(typeDefs, TypeDefinition{Name: toPascalCase(operationName), SchemaRef: schemaRef})
+		typeDefs = append(typeDefs, TypeDefinition{Name: toPascalCase(operationName), SchemaRef: requestBodies[operationID]})
+	}
+
+	// generate types for inline error response bodies
+	errorResponses := gatherErrorResponses(doc)
+	var errorTypeNames []string
+	for typeName := range errorResponses {
+		errorTypeNames = append(errorTypeNames, typeName)
+	}
+	sort.Strings(errorTypeNames)
+	for _, typeName := range errorTypeNames {
+		typeDefs = append(typeDefs, TypeDefinition{Name: typeName, SchemaRef: errorResponses[typeName]})
 	}
 
 	return typeDefs
@@ -47,9 +65,15 @@ func generateTypes(doc *openapi3.T, typeDefinitions []TypeDefinition) []byte {
 	typeBuf := bytes.Buffer{}
 	typeBuf.WriteString("// Auto-generated TypeScript types\n\n")
 
+	guardNames := make(map[string]bool)
 	for _, typeDef := range typeDefinitions {
 		ts, _ := generateTypeScript(typeDef.Name, typeDef.SchemaRef, doc)
 		typeBuf.WriteString(ts + "\n\n")
+
+		// Type guards for the variants of discriminated unions
+		for _, guard := range generateTypeGuards(typeDef.Name, typeDef.SchemaRef, guardNames) {
+			typeBuf.WriteString(guard + "\n\n")
+		}
 	}
 
 	return typeBuf.Bytes()
@@ -72,7 +96,7 @@ func gatherRequestBodies(doc *openapi3.T) map[string]*openapi3.SchemaRef {
 			"put":   pathItem.Put,
 		}
 
-		for _, op := range operations {
+		for method, op := range operations {
 			if op == nil {
 				continue
 			}
@@ -86,13 +110,10 @@ func gatherRequestBodies(doc *openapi3.T) map[string]*openapi3.SchemaRef {
 				if schemaRef == nil {
 					continue
 				}
-				if op.OperationID == "" {
-					continue // No operationId to name the interface
-				}
 				if schemaRef.RefPath() != nil && schemaRef.RefPath().String() != "" {
 					continue
 				}
-				result[op.OperationID] = schemaRef
+				result[generateMethodName(op, method, path)] = schemaRef
 			}
 
 			if len(op.RequestBody.Value.Content) > 0 && op.RequestBody.Value.Content["application/json"] != nil {
@@ -100,26 +121,69 @@ func gatherRequestBodies(doc *openapi3.T) map[string]*openapi3.SchemaRef {
 				if schemaRef == nil {
 					continue
 				}
-				if op.OperationID == "" {
-					continue // No operationId to name the interface
-				}
 				if schemaRef.RefPath() != nil && schemaRef.RefPath().String() != "" {
 					continue
 				}
-				result[op.OperationID] = schemaRef
+				result[generateMethodName(op, method, path)] = schemaRef
+			}
+		}
+	}
+	return result
+}
+
+// gatherErrorResponses scans all paths/operations for inline schemas of non-2xx responses
+// and returns a map of error type name -> SchemaRef
+func gatherErrorResponses(doc *openapi3.T) map[string]*openapi3.SchemaRef {
+	result := make(map[string]*openapi3.SchemaRef)
+
+	for _, path := range doc.Paths.InMatchingOrder() {
+		pathItem := doc.Paths.Find(path)
+		if pathItem == nil {
+			continue
+		}
+
+		operations := map[string]*openapi3.Operation{
+			"get":    pathItem.Get,
+			"post":   pathItem.Post,
+			"patch":  pathItem.Patch,
+			"put":    pathItem.Put,
+			"delete": pathItem.Delete,
+		}
+
+		for method, op := range operations {
+			if op == nil {
+				continue
+			}
+
+			for _, errorResponse := range determineErrorResponses(op, generateMethodName(op, method, path)) {
+				// Referenced schemas are already generated from components
+				if errorResponse.SchemaRef == nil || errorResponse.SchemaRef.Ref != "" {
+					continue
+				}
+				result[errorResponse.Type] = errorResponse.SchemaRef
 			}
 		}
 	}
 	return result
 }
 
-// generateTypeScript generates TypeScript interfaces/types from OpenAPI schemas
+// generateTypeScript generates TypeScript interfaces/types from OpenAPI schemas, documented with JSDoc
 func generateTypeScript(name string, schemaRef *openapi3.SchemaRef, doc *openapi3.T) (string, error) {
-	schema := schemaRef.Value
-	if schema == nil {
+	if schemaRef.Value == nil {
 		return "", fmt.Errorf("schema %s is nil", name)
 	}
 
+	declaration, err := generateTypeDeclaration(name, schemaRef, doc)
+	if err != nil {
+		return "", err
+	}
+	return renderJSDoc(schemaDocLines(schemaRef.Value), "") + declaration, nil
+}
+
+// generateTypeDeclaration generates the TypeScript interface/type declaration of an OpenAPI schema
+func generateTypeDeclaration(name string, schemaRef *openapi3.SchemaRef, doc *openapi3.T) (string, error) {
+	schema := schemaRef.Value
+
 	// Handle enums
 	if len(schema.Enum) > 0 {
 		enumValues := make([]string, len(schema.Enum))
@@ -138,24 +202,87 @@ func generateTypeScript(name string, schemaRef *openapi3.SchemaRef, doc *openapi
 		return fmt.Sprintf("export type %s = %s;", toPascalCase(name), strings.Join(enumValues, " | ")), nil
 	}
 
+	// Handle allOf composition as an intersection type
+	if len(schema.AllOf) > 0 {
+		tsType, err := resolveAllOf(schema, doc)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve allOf for %s: %v", name, err)
+		}
+		return fmt.Sprintf("export type %s = %s;", toPascalCase(name), tsType), nil
+	}
+
 	// Determine TypeScript type based on OpenAPI types
 	tsType, _ := resolveType(schemaRef, doc)
 
+	// Handle oneOf/anyOf unions, combined with the schema's own properties if any
+	if len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 {
+		if len(schema.Properties) > 0 {
+			props, err := collectProperties([]*openapi3.Schema{schema}, schema.Required, doc)
+			if err != nil {
+				return "", fmt.Errorf("failed to collect properties for %s: %v", name, err)
+			}
+			tsType = renderObjectLiteral(props, "") + " & " + wrapUnion(tsType)
+		}
+		return fmt.Sprintf("export type %s = %s;", toPascalCase(name), tsType), nil
+	}
+
 	// If the resolved type is an object with properties, define an interface
 	if isObject(schema) {
-		var buf bytes.Buffer
-		buf.WriteString(fmt.Sprintf("export interface %s {\n", toPascalCase(name)))
+		// Pure maps (additionalProperties without declared properties) become a Record
+		if len(schema.Properties) == 0 {
+			if mapType, ok := resolveMapType(schema, doc); ok {
+				return fmt.Sprintf("export type %s = %s;", toPascalCase(name), mapType), nil
+			}
+		}
+
+		allProps, err := collectProperties([]*openapi3.Schema{schema}, schema.Required, doc)
+		if err != nil {
+			return "", fmt.Errorf("failed to collect properties for %s: %v", name, err)
+		}
 
-		// PropertyInfo holds information about a property for sorting and output
-		type PropertyInfo struct {
-			camelName string
-			propType  string
-			optional  bool
-			nullable  bool
+		// Additional properties next to declared ones become an index signature
+		indexType := ""
+		if additionalType, ok := additionalPropertiesType(schema, doc); ok {
+			indexType = indexSignatureType(additionalType, allProps)
 		}
 
-		var allProps []PropertyInfo
+		var buf bytes.Buffer
+		buf.WriteString(fmt.Sprintf("export interface %s ", toPascalCase(name)))
+		buf.WriteString(renderObjectLiteral(allProps, indexType))
+		return buf.String(), nil
+	}
+
+	// For other types (e.g., arrays, primitives), define a type alias
+	return fmt.Sprintf("export type %s = %s;", toPascalCase(name), tsType), nil
+}
+
+// PropertyInfo holds information about a property for sorting and output
+type PropertyInfo struct {
+	name      string // Name of the property in the schema
+	camelName string
+	propType  string
+	optional  bool
+	nullable  bool
+	docLines  []string
+}
 
+// collectProperties gathers the properties of the given object schemas, sorted alphabetically.
+// Properties listed in required are mandatory; _embedded properties are promoted to top-level.
+func collectProperties(schemas []*openapi3.Schema, required []string, doc *openapi3.T) ([]PropertyInfo, error) {
+	var allProps []PropertyInfo
+	seen := make(map[string]int)
+
+	addProp := func(propInfo PropertyInfo) {
+		// Later schemas override earlier ones, as in an allOf composition
+		if i, ok := seen[propInfo.camelName]; ok {
+			allProps[i] = propInfo
+			return
+		}
+		seen[propInfo.camelName] = len(allProps)
+		allProps = append(allProps, propInfo)
+	}
+
+	for _, schema := range schemas {
 		// Collect regular properties (excluding _embedded)
 		for propName, prop := range schema.Properties {
 			if propName == "_embedded" {
@@ -163,7 +290,7 @@ func generateTypeScript(name string, schemaRef *openapi3.SchemaRef, doc *openapi
 			}
 
 			optional := true
-			if contains(schema.Required, propName) {
+			if contains(required, propName) {
 				optional = false
 			}
 			nullable := false
@@ -187,13 +314,14 @@ func generateTypeScript(name string, schemaRef *openapi3.SchemaRef, doc *openapi
 			}
 
 			propType, _ := resolveType(prop, doc)
-			camelPropName := toCamelCase(propName)
 
-			allProps = append(allProps, PropertyInfo{
-				camelName: camelPropName,
+			addProp(PropertyInfo{
+				name:      propName,
+				camelName: toCamelCase(propName),
 				propType:  propType,
 				optional:  optional,
 				nullable:  nullable,
+				docLines:  propertyDocLines(prop),
 			})
 		}
 
@@ -201,12 +329,11 @@ func generateTypeScript(name string, schemaRef *openapi3.SchemaRef, doc *openapi
 		if embeddedSchemaRef, ok := schema.Properties["_embedded"]; ok && embeddedSchemaRef != nil {
 			embeddedSchemaResolved, err := resolveSchemaRef(embeddedSchemaRef, doc)
 			if err != nil {
-				return "", fmt.Errorf("failed to resolve embedded $ref for %s: %v", name, err)
+				return nil, fmt.Errorf("failed to resolve embedded $ref: %v", err)
 			}
 			embeddedSchema := embeddedSchemaResolved.Value
 			if embeddedSchema != nil {
 				for embeddedPropName, embeddedProp := range embeddedSchema.Properties {
-					camelEmbeddedPropName := toCamelCase(embeddedPropName)
 					embeddedPropType, _ := resolveType(embeddedProp, doc)
 					optional := true
 					if contains(embeddedSchema.Required, embeddedPropName) {
@@ -218,40 +345,431 @@ func generateTypeScript(name string, schemaRef *openapi3.SchemaRef, doc *openapi
 						nullable = true
 					}
 
-					allProps = append(allProps, PropertyInfo{
-						camelName: camelEmbeddedPropName,
+					addProp(PropertyInfo{
+						name:      embeddedPropName,
+						camelName: toCamelCase(embeddedPropName),
 						propType:  embeddedPropType,
 						optional:  optional,
 						nullable:  nullable,
+						docLines:  propertyDocLines(embeddedProp),
 					})
 				}
 			}
 		}
+	}
 
-		// Sort all properties alphabetically by camelCase name
-		sort.Slice(allProps, func(i, j int) bool {
-			return allProps[i].camelName < allProps[j].camelName
-		})
+	// Sort all properties alphabetically by camelCase name
+	sort.Slice(allProps, func(i, j int) bool {
+		return allProps[i].camelName < allProps[j].camelName
+	})
 
-		// Output all properties in alphabetical order
-		for _, propInfo := range allProps {
-			if propInfo.optional {
-				if propInfo.nullable {
-					buf.WriteString(fmt.Sprintf("  %s?: %s | null;\n", propInfo.camelName, propInfo.propType))
-				} else {
-					buf.WriteString(fmt.Sprintf("  %s?: %s;\n", propInfo.camelName, propInfo.propType))
-				}
+	return allProps, nil
+}
+
+// renderObjectLiteral renders properties as a TypeScript object type body, e.g. "{\n  id: string;\n}".
+// A non-empty indexType adds an index signature for additional properties.
+func renderObjectLiteral(props []PropertyInfo, indexType string) string {
+	var buf bytes.Buffer
+	buf.WriteString("{\n")
+
+	// Output all properties in alphabetical order
+	for _, propInfo := range props {
+		buf.WriteString(renderJSDoc(propInfo.docLines, "  "))
+		propType := indentType(propInfo.propType, "  ")
+		if propInfo.optional {
+			if propInfo.nullable {
+				buf.WriteString(fmt.Sprintf("  %s?: %s | null;\n", propInfo.camelName, propType))
 			} else {
-				buf.WriteString(fmt.Sprintf("  %s: %s;\n", propInfo.camelName, propInfo.propType))
+				buf.WriteString(fmt.Sprintf("  %s?: %s;\n", propInfo.camelName, propType))
+			}
+		} else {
+			buf.WriteString(fmt.Sprintf("  %s: %s;\n", propInfo.camelName, propType))
+		}
+	}
+
+	if indexType != "" {
+		buf.WriteString(fmt.Sprintf("  [key: string]: %s;\n", indentType(indexType, "  ")))
+	}
+
+	buf.WriteString("}")
+	return buf.String()
+}
+
+// additionalPropertiesType returns the TypeScript type of the additional properties
+// allowed by an object schema, and false if it does not declare any
+func additionalPropertiesType(schema *openapi3.Schema, doc *openapi3.T) (string, bool) {
+	if schema.AdditionalProperties.Schema != nil {
+		tsType, _ := resolveType(schema.AdditionalProperties.Schema, doc)
+		return tsType, true
+	}
+	if schema.AdditionalProperties.Has != nil && *schema.AdditionalProperties.Has {
+		return typeMapping["object"], true
+	}
+	return "", false
+}
+
+// resolveMapType resolves an object schema without declared properties to a Record
+// type, e.g. Record<string, string> for translations keyed by locale
+func resolveMapType(schema *openapi3.Schema, doc *openapi3.T) (string, bool) {
+	if schema.Type != nil && !schema.Type.Includes("object") {
+		return "", false
+	}
+
+	if additionalType, ok := additionalPropertiesType(schema, doc); ok {
+		return fmt.Sprintf("Record<string, %s>", additionalType), true
+	}
+
+	// additionalProperties: false only produces a closed type in strict mode
+	if generatorOptions.StrictAdditionalProperties && schema.AdditionalProperties.Has != nil && !*schema.AdditionalProperties.Has {
+		return "Record<string, never>", true
+	}
+
+	return "", false
+}
+
+// indexSignatureType returns the type of an index signature combined with declared
+// properties, which TypeScript requires to be assignable to the index signature
+func indexSignatureType(additionalType string, props []PropertyInfo) string {
+	if additionalType == "any" {
+		return additionalType
+	}
+
+	tsTypes := []string{additionalType}
+	hasOptional := false
+	for _, propInfo := range props {
+		tsTypes = append(tsTypes, propInfo.propType)
+		if propInfo.nullable {
+			tsTypes = append(tsTypes, "null")
+		}
+		if propInfo.optional {
+			hasOptional = true
+		}
+	}
+	if hasOptional {
+		tsTypes = append(tsTypes, "undefined")
+	}
+
+	return strings.Join(removeDuplicates(tsTypes), " | ")
+}
+
+// propertyDocLines returns the JSDoc lines of a property. Referenced schemas are
+// documented on their own declaration, so only inline schemas are documented here.
+func propertyDocLines(prop *openapi3.SchemaRef) []string {
+	if prop == nil || prop.Ref != "" || prop.Value == nil {
+		return nil
+	}
+	return schemaDocLines(prop.Value)
+}
+
+// schemaDocLines returns the JSDoc lines describing a schema: its title/description,
+// deprecation, format, default, example and validation constraints
+func schemaDocLines(schema *openapi3.Schema) []string {
+	var lines []string
+
+	if schema.Title != "" {
+		lines = append(lines, strings.Split(strings.TrimSpace(schema.Title), "\n")...)
+	}
+	if schema.Description != "" {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		lines = append(lines, strings.Split(strings.TrimSpace(schema.Description), "\n")...)
+	}
+
+	var tags []string
+	if schema.Deprecated {
+		tags = append(tags, "@deprecated")
+	}
+	if schema.Format != "" {
+		tags = append(tags, "@format "+schema.Format)
+	}
+	if schema.Default != nil {
+		tags = append(tags, "@default "+docValue(schema.Default))
+	}
+	if schema.Example != nil {
+		tags = append(tags, "@example "+docValue(schema.Example))
+	}
+
+	// Validation constraints
+	if schema.MinLength > 0 {
+		tags = append(tags, fmt.Sprintf("@minLength %d", schema.MinLength))
+	}
+	if schema.MaxLength != nil {
+		tags = append(tags, fmt.Sprintf("@maxLength %d", *schema.MaxLength))
+	}
+	if schema.Pattern != "" {
+		tags = append(tags, "@pattern "+schema.Pattern)
+	}
+	if schema.Min != nil {
+		if schema.ExclusiveMin {
+			tags = append(tags, "@exclusiveMinimum "+strconv.FormatFloat(*schema.Min, 'f', -1, 64))
+		} else {
+			tags = append(tags, "@minimum "+strconv.FormatFloat(*schema.Min, 'f', -1, 64))
+		}
+	}
+	if schema.Max != nil {
+		if schema.ExclusiveMax {
+			tags = append(tags, "@exclusiveMaximum "+strconv.FormatFloat(*schema.Max, 'f', -1, 64))
+		} else {
+			tags = append(tags, "@maximum "+strconv.FormatFloat(*schema.Max, 'f', -1, 64))
+		}
+	}
+	if schema.MultipleOf != nil {
+		tags = append(tags, "@multipleOf "+strconv.FormatFloat(*schema.MultipleOf, 'f', -1, 64))
+	}
+	if schema.MinItems > 0 {
+		tags = append(tags, fmt.Sprintf("@minItems %d", schema.MinItems))
+	}
+	if schema.MaxItems != nil {
+		tags = append(tags, fmt.Sprintf("@maxItems %d", *schema.MaxItems))
+	}
+	if schema.UniqueItems {
+		tags = append(tags, "@uniqueItems")
+	}
+	if schema.ExternalDocs != nil && schema.ExternalDocs.URL != "" {
+		tags = append(tags, externalDocsTag(schema.ExternalDocs))
+	}
+
+	return append(lines, tags...)
+}
+
+// externalDocsTag renders external documentation as a JSDoc @see tag
+func externalDocsTag(externalDocs *openapi3.ExternalDocs) string {
+	if externalDocs.Description != "" {
+		return fmt.Sprintf("@see {@link %s | %s}", externalDocs.URL, strings.TrimSpace(externalDocs.Description))
+	}
+	return "@see " + externalDocs.URL
+}
+
+// docValue renders a default/example value for a JSDoc tag
+func docValue(value any) string {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+	return string(encoded)
+}
+
+// renderJSDoc renders lines as a JSDoc block at the given indentation, or nothing if there are no lines
+func renderJSDoc(lines []string, indent string) string {
+	if len(lines) == 0 {
+		return ""
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(indent + "/**\n")
+	for _, line := range lines {
+		// Prevent descriptions from closing the comment early
+		line = strings.ReplaceAll(strings.TrimRight(line, " \t\r"), "*/", "*\\/")
+		if line == "" {
+			buf.WriteString(indent + " *\n")
+		} else {
+			buf.WriteString(indent + " * " + line + "\n")
+		}
+	}
+	buf.WriteString(indent + " */\n")
+	return buf.String()
+}
+
+// indentType indents every line but the first of a multi-line type, so that nested
+// object literals line up with the property they belong to
+func indentType(tsType, indent string) string {
+	return strings.ReplaceAll(tsType, "\n", "\n"+indent)
+}
+
+// resolveAllOf resolves an allOf composition to an intersection of its members.
+// Referenced members are kept by name, while inline members and the schema's own
+// properties are merged into a single object literal. Required fields are merged
+// across all members.
+func resolveAllOf(schema *openapi3.Schema, doc *openapi3.T) (string, error) {
+	// Merge required fields declared by the schema itself and its inline members
+	required := append([]string{}, schema.Required...)
+	for _, member := range schema.AllOf {
+		if member != nil && member.Ref == "" && member.Value != nil {
+			required = append(required, member.Value.Required...)
+		}
+	}
+	required = removeDuplicates(required)
+
+	var parts []string
+	inlineSchemas := []*openapi3.Schema{}
+
+	for _, member := range schema.AllOf {
+		if member == nil || member.Value == nil {
+			continue
+		}
+
+		if member.Ref != "" {
+			refName := toPascalCase(getRefName(member.Ref))
+			parts = append(parts, refName)
+
+			// Fields made required by the composition but optional in the referenced schema
+			var promoted []string
+			for _, propName := range required {
+				if _, ok := member.Value.Properties[propName]; ok && !contains(member.Value.Required, propName) {
+					promoted = append(promoted, fmt.Sprintf("'%s'", toCamelCase(propName)))
+				}
+			}
+			if len(promoted) > 0 {
+				sort.Strings(promoted)
+				parts = append(parts, fmt.Sprintf("Required<Pick<%s, %s>>", refName, strings.Join(promoted, " | ")))
 			}
+			continue
+		}
+
+		if len(member.Value.AllOf) > 0 || len(member.Value.AnyOf) > 0 || len(member.Value.OneOf) > 0 {
+			memberType, _ := resolveType(member, doc)
+			parts = append(parts, wrapUnion(memberType))
+			continue
+		}
+
+		if len(member.Value.Properties) > 0 {
+			inlineSchemas = append(inlineSchemas, member.Value)
+			continue
+		}
+
+		if memberType, _ := resolveType(member, doc); memberType != "any" {
+			parts = append(parts, wrapUnion(memberType))
 		}
+	}
+
+	// The schema's own properties are merged with the inline members
+	if len(schema.Properties) > 0 {
+		inlineSchemas = append(inlineSchemas, schema)
+	}
+	if len(inlineSchemas) > 0 {
+		props, err := collectProperties(inlineSchemas, required, doc)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, renderObjectLiteral(props, ""))
+	}
+
+	if len(parts) == 0 {
+		return "any", nil
+	}
+	return strings.Join(removeDuplicates(parts), " & "), nil
+}
+
+// resolveUnion resolves oneOf/anyOf members to a union type. When a discriminator is set,
+// referenced members are narrowed with a literal-typed discriminant field, e.g.
+// (CardPayment & { type: 'card' }) | (BankTransferPayment & { type: 'bank_transfer' })
+func resolveUnion(members openapi3.SchemaRefs, discriminator *openapi3.Discriminator, resolve func(*openapi3.SchemaRef) string) string {
+	tsTypes := []string{}
+	for _, member := range members {
+		if member == nil {
+			continue
+		}
+
+		memberType := resolve(member)
+		if discriminator != nil && discriminator.PropertyName != "" && member.Ref != "" {
+			memberType = fmt.Sprintf("(%s & { %s: %s })", memberType, toCamelCase(discriminator.PropertyName), quoteLiteral(discriminatorValue(discriminator, member.Ref)))
+		}
+		tsTypes = append(tsTypes, memberType)
+	}
+
+	// Remove duplicate types
+	tsTypes = removeDuplicates(tsTypes)
+	if len(tsTypes) == 0 {
+		return "any"
+	}
+	// Join with | for union types
+	return strings.Join(tsTypes, " | ")
+}
+
+// discriminatorValue returns the discriminator value of a referenced union member,
+// taken from the mapping or, as per the spec, defaulting to the schema name
+func discriminatorValue(discriminator *openapi3.Discriminator, ref string) string {
+	refName := getRefName(ref)
+
+	// Iterate the mapping in a stable order, a schema may be mapped by several values
+	values := make([]string, 0, len(discriminator.Mapping))
+	for value := range discriminator.Mapping {
+		values = append(values, value)
+	}
+	sort.Strings(values)
+
+	for _, value := range values {
+		if getRefName(discriminator.Mapping[value]) == refName {
+			return value
+		}
+	}
+	return refName
+}
+
+// generateTypeGuards generates a type guard per variant of a discriminated union schema,
+// e.g. isCardPayment(value). Names already in use get the union name as prefix.
+func generateTypeGuards(name string, schemaRef *openapi3.SchemaRef, usedNames map[string]bool) []string {
+	if schemaRef == nil || schemaRef.Value == nil {
+		return nil
+	}
+	schema := schemaRef.Value
+	discriminator := schema.Discriminator
+	if discriminator == nil || discriminator.PropertyName == "" {
+		return nil
+	}
+
+	members := schema.OneOf
+	if len(members) == 0 {
+		members = schema.AnyOf
+	}
+
+	unionName := toPascalCase(name)
+	propName := toCamelCase(discriminator.PropertyName)
+
+	var guards []string
+	for _, member := range members {
+		if member == nil || member.Ref == "" {
+			continue
+		}
+
+		memberName := toPascalCase(getRefName(member.Ref))
+		guardName := "is" + memberName
+		if usedNames[guardName] {
+			guardName = "is" + unionName + memberName
+		}
+		if usedNames[guardName] {
+			continue
+		}
+		usedNames[guardName] = true
+
+		value := quoteLiteral(discriminatorValue(discriminator, member.Ref))
 
+		var buf bytes.Buffer
+		buf.WriteString("/**\n")
+		buf.WriteString(fmt.Sprintf(" * Type guard for the %s variant of %s\n", value, unionName))
+		buf.WriteString(" */\n")
+		buf.WriteString(fmt.Sprintf("export function %s(value: %s): value is Extract<%s, { %s: %s }> {\n", guardName, unionName, unionName, propName, value))
+		buf.WriteString(fmt.Sprintf("  return value.%s === %s;\n", propName, value))
 		buf.WriteString("}")
-		return buf.String(), nil
+		guards = append(guards, buf.String())
 	}
 
-	// For other types (e.g., arrays, primitives), define a type alias
-	return fmt.Sprintf("export type %s = %s;", toPascalCase(name), tsType), nil
+	return guards
+}
+
+// quoteLiteral renders a string as a single-quoted TypeScript literal
+func quoteLiteral(value string) string {
+	value = strings.ReplaceAll(value, "\\", "\\\\")
+	return "'" + strings.ReplaceAll(value, "'", "\\'") + "'"
+}
+
+// wrapUnion wraps a union type in parentheses so it can be used in an intersection or array
+func wrapUnion(tsType string) string {
+	depth := 0
+	for i, r := range tsType {
+		switch r {
+		case '{', '(', '<', '[':
+			depth++
+		case '}', ')', '>', ']':
+			depth--
+		case '|', '&':
+			if depth == 0 && i > 0 {
+				return "(" + tsType + ")"
+			}
+		}
+	}
+	return tsType
 }
 
 // resolveSchemaRef resolves a $ref SchemaRef to the actual SchemaRef in the document
@@ -279,6 +797,9 @@ func isObject(schema *openapi3.Schema) bool {
 	if schema.Properties != nil && len(schema.Properties) > 0 {
 		return true
 	}
+	if schema.AdditionalProperties.Schema != nil || schema.AdditionalProperties.Has != nil {
+		return true
+	}
 	return false
 }
 
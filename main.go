@@ -6,7 +6,9 @@ import (
 	"io"
 	"log"
 	"os"
-	"path/filepath"
+	"os/signal"
+	"strings"
+	"syscall"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
@@ -14,15 +16,27 @@ import (
 const version = "1.0.0"
 
 var (
+	configPath  string
 	docPath     string
 	outputDir   string
+	sdkFile     = "sdk.ts"
+	checkOnly   bool
+	watch       bool
+	strict      bool
 	showVersion bool
 )
 
 func init() {
+	flag.StringVar(&configPath, "config", "", "Path to the configuration file. Defaults to "+defaultConfigFile+" in the working directory, if present.")
 	flag.StringVar(&docPath, "doc", "-", "Path to the OpenAPI document file. Use '-' to read from stdin.")
 	flag.StringVar(&outputDir, "o", "./src", "Output directory where the generated files will be placed.")
+	flag.BoolVar(&checkOnly, "check", false, "Check that the files in the output directory are up to date, printing a diff of the stale ones, without writing anything. Exits with status 1 on drift.")
+	flag.BoolVar(&watch, "watch", false, "Regenerate the SDK whenever the OpenAPI document or a file it references changes.")
+	flag.BoolVar(&strict, "strict", false, "Fail on the warnings of the OpenAPI document validation, not only on its errors.")
 	flag.BoolVar(&showVersion, "version", false, "Show version information and exit.")
+	flag.BoolVar(&generatorOptions.GroupByTag, "group-by-tag", false, "Group operations into resource sub-clients by their first tag (or x-gocart-resource extension).")
+	flag.BoolVar(&generatorOptions.StrictAdditionalProperties, "strict-additional-properties", false, "Generate closed object types for schemas with 'additionalProperties: false'.")
+	flag.StringVar(&generatorOptions.ClassName, "class-name", generatorOptions.ClassName, "Name of the generated SDK class.")
 }
 
 func main() {
@@ -33,6 +47,32 @@ func main() {
 		os.Exit(0)
 	}
 
+	if err := applyConfigFile(findConfigFile(configPath)); err != nil {
+		log.Fatalf("Invalid configuration: %v", err)
+	}
+	if !identifierPattern.MatchString(generatorOptions.ClassName) {
+		log.Fatalf("Invalid class name %q: not a valid TypeScript identifier", generatorOptions.ClassName)
+	}
+
+	if watch {
+		if docPath == "-" {
+			log.Fatalf("-watch needs an OpenAPI document file, set with -doc")
+		}
+		if checkOnly {
+			log.Fatalf("-watch and -check cannot be used together")
+		}
+		stop := make(chan os.Signal, 1)
+		signal.Notify(stop, os.Interrupt, syscall.SIGTERM)
+		done := make(chan struct{})
+		go func() {
+			<-stop
+			close(done)
+		}()
+		watchDocument(docPath, outputDir, watchInterval, watchDebounce, done, log.Printf)
+		return
+	}
+
+	var doc *openapi3.T
 	var docData []byte
 	var err error
 
@@ -42,48 +82,93 @@ func main() {
 		if err != nil {
 			log.Fatalf("Failed to read OpenAPI spec from stdin: %v", err)
 		}
+
+		loader := openapi3.NewLoader()
+		loader.IsExternalRefsAllowed = true
+		doc, err = loader.LoadFromData(docData)
+		if err != nil {
+			log.Fatalf("Failed to load OpenAPI document: %v", err)
+		}
 	} else {
-		// Read from file
-		docData, err = os.ReadFile(docPath)
+		// Read from file, resolving external references against its directory
+		doc, _, err = loadDocumentFile(docPath)
 		if err != nil {
-			log.Fatalf("Failed to read OpenAPI spec from file %s: %v", docPath, err)
+			log.Fatalf("Failed to load OpenAPI document %s: %v", docPath, err)
+		}
+		if docData, err = os.ReadFile(docPath); err != nil {
+			log.Fatalf("Failed to read OpenAPI document %s: %v", docPath, err)
 		}
 	}
 
-	loader := openapi3.NewLoader()
-	loader.IsExternalRefsAllowed = true
-
-	doc, err := loader.LoadFromData(docData)
-	if err != nil {
-		log.Fatalf("Failed to load OpenAPI document: %v", err)
+	// Validate the document before generating anything
+	docName := docPath
+	if docPath == "-" {
+		docName = "<stdin>"
+	}
+	printf := func(format string, args ...any) {
+		fmt.Fprintf(os.Stderr, format+"\n", args...)
+	}
+	if !reportDiagnostics(validateDocument(doc, docData, docName), strict, printf) {
+		os.Exit(1)
 	}
 
 	// Generate code
-	typeDefinitions := getTypeDefinitions(doc)
-	paramDefinitions := getParamDefinitions(doc)
-
-	sdkBytes := generateSDK(doc, typeDefinitions, paramDefinitions)
-	typesBytes := generateTypes(doc, typeDefinitions)
-	paramBytes := generateParams(doc, paramDefinitions)
-
-	// Ensure output directory structure
-	srcDir := filepath.Join(outputDir)
-	err = os.MkdirAll(srcDir, 0755)
+	files, err := renderSDK(doc)
 	if err != nil {
-		log.Fatalf("Failed to create output directory %s: %v", srcDir, err)
+		log.Fatalf("Failed to generate SDK: %v", err)
+	}
+
+	if checkOnly {
+		stale, err := checkOutput(outputDir, files, os.Stdout)
+		if err != nil {
+			log.Fatalf("Failed to check generated SDK: %v", err)
+		}
+		if len(stale) > 0 {
+			log.Printf("Generated SDK in %s is out of date: %s\n", outputDir, strings.Join(stale, ", "))
+			os.Exit(1)
+		}
+		log.Printf("Generated SDK in %s is up to date\n", outputDir)
+		return
 	}
 
-	// Write files
-	writeFile(filepath.Join(srcDir, "sdk.ts"), sdkBytes)
-	writeFile(filepath.Join(srcDir, "types.ts"), typesBytes)
-	writeFile(filepath.Join(srcDir, "params.ts"), paramBytes)
+	if _, err := writeOutput(outputDir, files); err != nil {
+		log.Fatal(err)
+	}
 
 	log.Printf("Hey! Generated TypeScript SDK in %s\n", outputDir)
 }
 
-func writeFile(path string, data []byte) {
-	err := os.WriteFile(path, data, 0644)
+// applyConfigFile applies the settings of a configuration file, if any. The flags set on the
+// command line are applied again afterwards so they override the file.
+func applyConfigFile(path string) error {
+	if path == "" {
+		return nil
+	}
+	config, err := loadConfig(path)
 	if err != nil {
-		log.Fatalf("Failed to write %s: %v", path, err)
+		return err
+	}
+
+	setFlags := make(map[string]string)
+	flag.Visit(func(f *flag.Flag) {
+		setFlags[f.Name] = f.Value.String()
+	})
+
+	if config.Doc != "" {
+		docPath = config.Doc
+	}
+	if config.Output.Dir != "" {
+		outputDir = config.Output.Dir
+	}
+	if config.Output.SDKFile != "" {
+		sdkFile = config.Output.SDKFile
+	}
+	config.apply(&generatorOptions)
+
+	for name, value := range setFlags {
+		if err := flag.Set(name, value); err != nil {
+			return err
+		}
 	}
+	return nil
 }
@@ -0,0 +1,59 @@
+package main
+
+// GeneratorOptions controls optional behaviour of the generator
+type GeneratorOptions struct {
+	// StrictAdditionalProperties honours `additionalProperties: false` by generating
+	// closed object types (Record<string, never>) for schemas without properties
+	StrictAdditionalProperties bool
+
+	// GroupByTag groups operations into resource sub-clients by their first tag
+	// (or x-gocart-resource extension), e.g. sdk.products.list()
+	GroupByTag bool
+
+	// Pagination generates the auto-pagination methods of list operations
+	Pagination bool
+
+	// WithResponse generates the WithResponse variant of every method
+	WithResponse bool
+
+	// ClassName is the name of the SDK class, also prefixing its options types (e.g. GoCartSDKOptions)
+	ClassName string
+
+	// DefaultBaseURL is the base URL of the SDK when the document declares no servers
+	DefaultBaseURL string
+
+	// SDKVersionHeader is the request header carrying the version of the SDK
+	SDKVersionHeader string
+
+	// TotalCountHeader is the header requesting, and then carrying, the total number of items of a list
+	TotalCountHeader string
+
+	// IncludeFallback are the values of include parameters declaring no enum, when none are
+	// declared with x-gocart-includes or derived from the response
+	IncludeFallback []string
+
+	// IncludeDepth is the depth of the include values derived from the _embedded relationships
+	// of responses, e.g. 2 for items.product, 0 disabling them
+	IncludeDepth int
+}
+
+// defaultGeneratorOptions returns the options used when neither flags nor a configuration file set them
+func defaultGeneratorOptions() GeneratorOptions {
+	return GeneratorOptions{
+		Pagination:       true,
+		WithResponse:     true,
+		ClassName:        "GoCartSDK",
+		DefaultBaseURL:   "https://api.orbita.al",
+		SDKVersionHeader: "x-gocart-sdk-version",
+		TotalCountHeader: "Collection-Total",
+		IncludeDepth:     3,
+	}
+}
+
+// generatorOptions holds the options of the current run, set from the configuration file and the command-line flags
+var generatorOptions = defaultGeneratorOptions()
+
+// sdkTypeName returns the name of a type of the SDK class, e.g. sdkTypeName("Options") -> GoCartSDKOptions
+func sdkTypeName(suffix string) string {
+	return generatorOptions.ClassName + suffix
+}
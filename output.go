@@ -0,0 +1,105 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// OutputFile is a file of the generated SDK, named relative to the output directory
+type OutputFile struct {
+	Name    string
+	Content []byte
+}
+
+// renderSDK generates all the files of the SDK in memory: the SDK class, the types, the
+// params and the runtime modules they import
+func renderSDK(doc *openapi3.T) ([]OutputFile, error) {
+	typeDefinitions := getTypeDefinitions(doc)
+	paramDefinitions := getParamDefinitions(doc)
+
+	files := []OutputFile{
+		{Name: sdkFile, Content: generateSDK(doc, typeDefinitions, paramDefinitions)},
+		{Name: "types.ts", Content: generateTypes(doc, typeDefinitions)},
+		{Name: "params.ts", Content: generateParams(doc, paramDefinitions)},
+	}
+
+	runtimeFiles, err := getRuntimeFiles()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read runtime modules: %w", err)
+	}
+	for _, f := range runtimeFiles {
+		files = append(files, OutputFile{Name: f.Name, Content: f.Content})
+	}
+
+	return files, nil
+}
+
+// writeOutput writes the generated files to the output directory, creating it if needed.
+// Files whose content did not change are left untouched; the names of the written files are returned.
+func writeOutput(dir string, files []OutputFile) ([]string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create output directory %s: %w", dir, err)
+	}
+	var written []string
+	for _, f := range files {
+		path := filepath.Join(dir, f.Name)
+		if current, err := os.ReadFile(path); err == nil && bytes.Equal(current, f.Content) {
+			continue
+		}
+		if err := os.WriteFile(path, f.Content, 0644); err != nil {
+			return written, fmt.Errorf("failed to write %s: %w", path, err)
+		}
+		written = append(written, f.Name)
+	}
+	return written, nil
+}
+
+// checkOutput compares the generated files with the files of the output directory without
+// writing anything. It prints a unified diff of every stale or missing file to w, and returns
+// the names of these files.
+func checkOutput(dir string, files []OutputFile, w io.Writer) ([]string, error) {
+	var stale []string
+	for _, f := range files {
+		path := filepath.Join(dir, f.Name)
+
+		current, err := os.ReadFile(path)
+		fromFile := path
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			current, fromFile = nil, os.DevNull
+		case err != nil:
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		if bytes.Equal(current, f.Content) {
+			continue
+		}
+
+		var currentLines []string
+		if len(current) > 0 {
+			currentLines = difflib.SplitLines(string(current))
+		}
+		diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        currentLines,
+			B:        difflib.SplitLines(string(f.Content)),
+			FromFile: fromFile,
+			ToFile:   path + " (generated)",
+			Context:  3,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to diff %s: %w", path, err)
+		}
+		if _, err := io.WriteString(w, diff); err != nil {
+			return nil, err
+		}
+		stale = append(stale, f.Name)
+	}
+	return stale, nil
+}
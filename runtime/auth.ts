@@ -0,0 +1,243 @@
+// Auto-generated TypeScript SDK runtime
+// Do not modify manually.
+
+import { ApiError } from './error';
+import { applyQuery } from './request';
+import { toClientType } from './utils';
+
+/**
+ * TokenProvider is a token, or a function returning the current token
+ */
+export type TokenProvider = string | (() => string | Promise<string>);
+
+/**
+ * BasicCredentials are the credentials of an HTTP basic security scheme
+ */
+export interface BasicCredentials {
+  username: string;
+  password: string;
+}
+
+/**
+ * OAuth2ClientCredentials obtain access tokens from the token endpoint of an OAuth2 security
+ * scheme, using the refresh token flow when a refresh token is given and the client
+ * credentials flow otherwise
+ */
+export interface OAuth2ClientCredentials {
+  clientId: string;
+  clientSecret?: string;
+  scopes?: string[];
+  refreshToken?: string;
+
+  /**
+   * Override the token URL declared by the API
+   */
+  tokenUrl?: string;
+
+  /**
+   * Override the refresh URL declared by the API
+   */
+  refreshUrl?: string;
+}
+
+/**
+ * OAuth2Credentials are an access token, or client credentials to obtain one
+ */
+export type OAuth2Credentials = TokenProvider | OAuth2ClientCredentials;
+
+/**
+ * SecurityScheme describes how credentials are sent to the API
+ */
+export type SecurityScheme =
+  | { type: 'http'; scheme: 'bearer' | 'basic' }
+  | { type: 'apiKey'; in: 'header' | 'query' | 'cookie'; name: string }
+  | { type: 'oauth2'; tokenUrl?: string; refreshUrl?: string };
+
+interface OAuth2Token {
+  accessToken: string;
+  expiresAt?: number;
+  refreshToken?: string;
+}
+
+// Tokens are refreshed this long before they expire
+const TOKEN_EXPIRY_MARGIN_MS = 30_000;
+
+/**
+ * AuthManager applies the credentials of the security schemes required by an operation
+ */
+export class AuthManager {
+  private credentials: Record<string, any>;
+  private tokens = new Map<string, OAuth2Token>();
+  private pendingTokens = new Map<string, Promise<string>>();
+
+  constructor(
+    private readonly schemes: Record<string, SecurityScheme>,
+    credentials: object = {},
+  ) {
+    this.credentials = { ...credentials };
+  }
+
+  /**
+   * Replace the credentials, dropping cached OAuth2 tokens
+   */
+  public setCredentials(credentials: object): void {
+    this.credentials = { ...credentials };
+    this.tokens.clear();
+    this.pendingTokens.clear();
+  }
+
+  /**
+   * Apply the credentials of the first security requirement that can be satisfied.
+   * Each requirement lists the schemes that must all be applied; requests are sent
+   * without credentials when no requirement can be satisfied. OAuth2 tokens are requested
+   * with the fetch implementation of the request, the global fetch by default.
+   */
+  public async apply(
+    requirements: string[][],
+    url: string,
+    options: RequestInit,
+    fetchImpl: typeof fetch = fetch,
+  ): Promise<{ url: string; options: RequestInit }> {
+    const requirement = requirements.find(
+      (names) =>
+        names.length > 0 &&
+        names.every((name) => this.schemes[name] !== undefined && this.credentials[name] !== undefined),
+    );
+    if (!requirement) {
+      return { url, options };
+    }
+
+    const headers = new Headers(options.headers);
+    const cookies: string[] = [];
+    let finalUrl = url;
+
+    for (const name of requirement) {
+      const scheme = this.schemes[name];
+      const credential = this.credentials[name];
+
+      switch (scheme.type) {
+        case 'http':
+          if (scheme.scheme === 'basic') {
+            const { username, password } = credential as BasicCredentials;
+            headers.set('Authorization', `Basic ${encodeBase64(`${username}:${password}`)}`);
+          } else {
+            headers.set('Authorization', `Bearer ${await resolveToken(credential)}`);
+          }
+          break;
+        case 'apiKey': {
+          const value = await resolveToken(credential);
+          if (scheme.in === 'query') {
+            // Relative base URLs, e.g. /api behind a same-origin proxy, can't be parsed by URL
+            finalUrl = applyQuery(finalUrl, { [scheme.name]: value });
+          } else if (scheme.in === 'cookie') {
+            cookies.push(`${scheme.name}=${encodeURIComponent(value)}`);
+          } else {
+            headers.set(scheme.name, value);
+          }
+          break;
+        }
+        case 'oauth2':
+          headers.set('Authorization', `Bearer ${await this.oauth2Token(name, scheme, credential, fetchImpl)}`);
+          break;
+      }
+    }
+
+    if (cookies.length > 0) {
+      const existing = headers.get('Cookie');
+      headers.set('Cookie', [existing, ...cookies].filter(Boolean).join('; '));
+    }
+
+    return {
+      url: finalUrl,
+      options: { ...options, headers: Object.fromEntries(headers.entries()) },
+    };
+  }
+
+  /**
+   * Get an OAuth2 access token, fetching a new one when there is none or it expires soon
+   */
+  private async oauth2Token(
+    name: string,
+    scheme: { tokenUrl?: string; refreshUrl?: string },
+    credential: OAuth2Credentials,
+    fetchImpl: typeof fetch,
+  ): Promise<string> {
+    if (typeof credential === 'string' || typeof credential === 'function') {
+      return resolveToken(credential);
+    }
+
+    const cached = this.tokens.get(name);
+    if (cached && (cached.expiresAt === undefined || cached.expiresAt > Date.now() + TOKEN_EXPIRY_MARGIN_MS)) {
+      return cached.accessToken;
+    }
+
+    // Share a single token request between concurrent requests
+    let pending = this.pendingTokens.get(name);
+    if (!pending) {
+      pending = this.fetchToken(name, scheme, credential, fetchImpl, cached?.refreshToken ?? credential.refreshToken).finally(() => {
+        this.pendingTokens.delete(name);
+      });
+      this.pendingTokens.set(name, pending);
+    }
+    return pending;
+  }
+
+  private async fetchToken(
+    name: string,
+    scheme: { tokenUrl?: string; refreshUrl?: string },
+    credential: OAuth2ClientCredentials,
+    fetchImpl: typeof fetch,
+    refreshToken?: string,
+  ): Promise<string> {
+    const body = new URLSearchParams();
+    let tokenUrl = credential.tokenUrl ?? scheme.tokenUrl;
+    if (refreshToken) {
+      body.set('grant_type', 'refresh_token');
+      body.set('refresh_token', refreshToken);
+      tokenUrl = credential.refreshUrl ?? scheme.refreshUrl ?? tokenUrl;
+    } else {
+      body.set('grant_type', 'client_credentials');
+      if (credential.scopes && credential.scopes.length > 0) {
+        body.set('scope', credential.scopes.join(' '));
+      }
+    }
+    body.set('client_id', credential.clientId);
+    if (credential.clientSecret) {
+      body.set('client_secret', credential.clientSecret);
+    }
+
+    if (!tokenUrl) {
+      throw new Error(`No token URL for the ${name} security scheme`);
+    }
+
+    const response = await fetchImpl(tokenUrl, {
+      method: 'POST',
+      headers: { 'Content-Type': 'application/x-www-form-urlencoded' },
+      body,
+    });
+    if (!response.ok) {
+      const errMessage = await response.json().catch(() => null);
+      throw new ApiError(response.status, toClientType(errMessage));
+    }
+
+    const token = await response.json();
+    this.tokens.set(name, {
+      accessToken: token.access_token,
+      expiresAt: token.expires_in ? Date.now() + token.expires_in * 1000 : undefined,
+      refreshToken: token.refresh_token ?? refreshToken,
+    });
+    return token.access_token;
+  }
+}
+
+async function resolveToken(provider: TokenProvider): Promise<string> {
+  return typeof provider === 'function' ? provider() : provider;
+}
+
+function encodeBase64(value: string): string {
+  let binary = '';
+  new TextEncoder().encode(value).forEach((byte) => {
+    binary += String.fromCharCode(byte);
+  });
+  return btoa(binary);
+}
@@ -0,0 +1,54 @@
+// Auto-generated TypeScript SDK runtime
+// Do not modify manually.
+
+/**
+ * InMemoryContext holds headers that are attached to every request made by the SDK,
+ * e.g. the current store, locale or an authorization token set at runtime.
+ */
+export class InMemoryContext {
+  private headers: Record<string, string> = {};
+
+  /**
+   * Set a header that will be sent with every request
+   */
+  public setHeader(name: string, value: string): void {
+    this.headers[name] = value;
+  }
+
+  /**
+   * Remove a previously set header
+   */
+  public removeHeader(name: string): void {
+    delete this.headers[name];
+  }
+
+  /**
+   * Get a copy of all headers currently held by the context
+   */
+  public getHeaders(): Record<string, string> {
+    return { ...this.headers };
+  }
+
+  /**
+   * Remove all headers held by the context
+   */
+  public clear(): void {
+    this.headers = {};
+  }
+
+  /**
+   * Merge the context headers into the given request options.
+   * Headers already present on the request take precedence.
+   */
+  public setHttpRequestHeaders(options: RequestInit): RequestInit {
+    const headers = new Headers(this.headers);
+    new Headers(options.headers).forEach((value, key) => {
+      headers.set(key, value);
+    });
+
+    return {
+      ...options,
+      headers: Object.fromEntries(headers.entries()),
+    };
+  }
+}
@@ -0,0 +1,112 @@
+// Auto-generated TypeScript SDK runtime
+// Do not modify manually.
+
+/**
+ * FieldError describes a validation error for a single request field
+ */
+export interface FieldError {
+  field: string;
+  message: string;
+  code?: string;
+}
+
+/**
+ * Redirection status codes, typing the status of 3XX error responses
+ */
+export type RedirectionStatus =
+  | 300 | 301 | 302 | 303 | 304 | 305 | 306 | 307 | 308 | 309
+  | 310 | 311 | 312 | 313 | 314 | 315 | 316 | 317 | 318 | 319
+  | 320 | 321 | 322 | 323 | 324 | 325 | 326 | 327 | 328 | 329
+  | 330 | 331 | 332 | 333 | 334 | 335 | 336 | 337 | 338 | 339
+  | 340 | 341 | 342 | 343 | 344 | 345 | 346 | 347 | 348 | 349
+  | 350 | 351 | 352 | 353 | 354 | 355 | 356 | 357 | 358 | 359
+  | 360 | 361 | 362 | 363 | 364 | 365 | 366 | 367 | 368 | 369
+  | 370 | 371 | 372 | 373 | 374 | 375 | 376 | 377 | 378 | 379
+  | 380 | 381 | 382 | 383 | 384 | 385 | 386 | 387 | 388 | 389
+  | 390 | 391 | 392 | 393 | 394 | 395 | 396 | 397 | 398 | 399;
+
+/**
+ * Client error status codes, typing the status of 4XX error responses
+ */
+export type ClientErrorStatus =
+  | 400 | 401 | 402 | 403 | 404 | 405 | 406 | 407 | 408 | 409
+  | 410 | 411 | 412 | 413 | 414 | 415 | 416 | 417 | 418 | 419
+  | 420 | 421 | 422 | 423 | 424 | 425 | 426 | 427 | 428 | 429
+  | 430 | 431 | 432 | 433 | 434 | 435 | 436 | 437 | 438 | 439
+  | 440 | 441 | 442 | 443 | 444 | 445 | 446 | 447 | 448 | 449
+  | 450 | 451 | 452 | 453 | 454 | 455 | 456 | 457 | 458 | 459
+  | 460 | 461 | 462 | 463 | 464 | 465 | 466 | 467 | 468 | 469
+  | 470 | 471 | 472 | 473 | 474 | 475 | 476 | 477 | 478 | 479
+  | 480 | 481 | 482 | 483 | 484 | 485 | 486 | 487 | 488 | 489
+  | 490 | 491 | 492 | 493 | 494 | 495 | 496 | 497 | 498 | 499;
+
+/**
+ * Server error status codes, typing the status of 5XX error responses
+ */
+export type ServerErrorStatus =
+  | 500 | 501 | 502 | 503 | 504 | 505 | 506 | 507 | 508 | 509
+  | 510 | 511 | 512 | 513 | 514 | 515 | 516 | 517 | 518 | 519
+  | 520 | 521 | 522 | 523 | 524 | 525 | 526 | 527 | 528 | 529
+  | 530 | 531 | 532 | 533 | 534 | 535 | 536 | 537 | 538 | 539
+  | 540 | 541 | 542 | 543 | 544 | 545 | 546 | 547 | 548 | 549
+  | 550 | 551 | 552 | 553 | 554 | 555 | 556 | 557 | 558 | 559
+  | 560 | 561 | 562 | 563 | 564 | 565 | 566 | 567 | 568 | 569
+  | 570 | 571 | 572 | 573 | 574 | 575 | 576 | 577 | 578 | 579
+  | 580 | 581 | 582 | 583 | 584 | 585 | 586 | 587 | 588 | 589
+  | 590 | 591 | 592 | 593 | 594 | 595 | 596 | 597 | 598 | 599;
+
+/**
+ * Status codes of the responses throwing an ApiError, typing the status of default error responses
+ */
+export type ErrorStatus = RedirectionStatus | ClientErrorStatus | ServerErrorStatus;
+
+/**
+ * ApiError is thrown by the SDK when the API responds with a non-2xx status code.
+ *
+ * `status` and `body` are typed per operation by the generated `<Operation>Error`
+ * unions, so callers can narrow on the status code:
+ *
+ * ```ts
+ * try {
+ *   await sdk.createCart(req);
+ * } catch (err) {
+ *   if (isApiError<CreateCartError>(err) && err.status === 409) {
+ *     console.log(err.body);
+ *   }
+ * }
+ * ```
+ */
+export class ApiError<S extends number = number, B = any> extends Error {
+  public readonly status: S;
+  public readonly body: B;
+  public readonly code: string;
+  public readonly fieldErrors: FieldError[];
+
+  constructor(status: S, body: B) {
+    super(ApiError.messageFrom(status, body));
+    this.name = 'ApiError';
+    this.status = status;
+    this.body = body;
+
+    const payload: any = body ?? {};
+    this.code = typeof payload.code === 'string' ? payload.code : String(status);
+    this.fieldErrors = Array.isArray(payload.fieldErrors) ? payload.fieldErrors : [];
+    Object.setPrototypeOf(this, ApiError.prototype);
+  }
+
+  private static messageFrom(status: number, body: unknown): string {
+    const payload: any = body ?? {};
+    if (typeof payload.message === 'string') {
+      return payload.message;
+    }
+    return `Request failed with status ${status}`;
+  }
+}
+
+/**
+ * Type guard to check if an error was thrown by the SDK.
+ * Pass the operation error union to get a typed `status` and `body`.
+ */
+export function isApiError<E extends ApiError = ApiError>(error: unknown): error is E {
+  return error instanceof ApiError;
+}
@@ -0,0 +1,28 @@
+// Auto-generated TypeScript SDK runtime
+// Do not modify manually.
+
+/**
+ * First segment of include paths, e.g. items for items.product
+ */
+type IncludeHead<I extends string> = I extends `${infer H}.${string}` ? H : I;
+
+/**
+ * Rest of the include paths starting with a segment, e.g. product for items.product
+ */
+type IncludeTail<I extends string, K> = I extends `${K & string}.${infer R}` ? R : never;
+
+/**
+ * Included marks the relationships requested with include as present in a resource, the
+ * relationships of lists being marked in each of their items, e.g.
+ * Included<Order, 'items' | 'items.product'> makes the items of the order and the product of
+ * every item required
+ */
+export type Included<T, I extends string> = [I] extends [never]
+  ? T
+  : T extends readonly (infer U)[]
+    ? Included<U, I>[]
+    : T extends object
+      ? Omit<T, IncludeHead<I> & keyof T> & {
+          [K in IncludeHead<I> & keyof T]-?: Included<Exclude<T[K], undefined>, IncludeTail<I, K>>;
+        }
+      : T;
@@ -0,0 +1,50 @@
+// Auto-generated TypeScript SDK runtime
+// Do not modify manually.
+
+import { RetryRequest } from './params';
+
+/**
+ * RequestInterceptor can replace the request options and/or URL before the request is sent
+ */
+export type RequestInterceptor = (
+  options: RequestInit,
+  url: string,
+) => Promise<{ options?: RequestInit; url?: string } | void> | { options?: RequestInit; url?: string } | void;
+
+/**
+ * ResponseInterceptor can replace the response or ask the SDK to retry the request
+ */
+export type ResponseInterceptor = (
+  response: Response,
+  options: RequestInit,
+  url: string,
+) => Promise<Response | RetryRequest | void> | Response | RetryRequest | void;
+
+/**
+ * InterceptorManager keeps an ordered list of interceptors
+ */
+export class InterceptorManager<T> {
+  public interceptors: T[] = [];
+
+  /**
+   * Register an interceptor and return a function that removes it
+   */
+  public use(interceptor: T): () => void {
+    this.interceptors.push(interceptor);
+    return () => this.eject(interceptor);
+  }
+
+  /**
+   * Remove a previously registered interceptor
+   */
+  public eject(interceptor: T): void {
+    this.interceptors = this.interceptors.filter((i) => i !== interceptor);
+  }
+
+  /**
+   * Remove all interceptors
+   */
+  public clear(): void {
+    this.interceptors = [];
+  }
+}
@@ -0,0 +1,131 @@
+// Auto-generated TypeScript SDK runtime
+// Do not modify manually.
+
+import { RequestOptions } from './request';
+
+/**
+ * PaginationStrategy selects how the next page of a list operation is requested:
+ * - page: increments the page[number] query parameter
+ * - cursor: follows the links.next URL of the response body
+ * - link: follows the rel="next" URL of the Link response header
+ */
+export type PaginationStrategy = 'page' | 'cursor' | 'link';
+
+/**
+ * PaginationOptions configures auto-pagination of list operations. The request options
+ * apply to every page request; the signal aborts the whole iteration.
+ */
+export interface PaginationOptions extends Omit<RequestOptions, 'raw' | 'pageUrl'> {
+  /**
+   * Stop after yielding this many items
+   */
+  maxItems?: number;
+}
+
+/**
+ * Page is a single page of a list operation
+ */
+export interface Page<T> {
+  items: T[];
+  body: any;
+  response: Response;
+}
+
+/**
+ * PageCursor identifies the page to fetch: a page number or the URL of the next page
+ */
+export interface PageCursor {
+  number?: number;
+  url?: string;
+}
+
+/**
+ * Walk the pages of a list operation and yield their items
+ */
+export async function* paginate<T>(
+  strategy: PaginationStrategy,
+  fetchPage: (cursor: PageCursor) => Promise<Page<T>>,
+  options: PaginationOptions = {},
+  startPage?: number,
+): AsyncGenerator<T, void, undefined> {
+  let cursor: PageCursor = strategy === 'page' ? { number: startPage ?? 1 } : {};
+  let pageSize: number | undefined;
+  let count = 0;
+
+  while (true) {
+    throwIfAborted(options.signal);
+
+    const page = await fetchPage(cursor);
+    for (const item of page.items) {
+      if (options.maxItems !== undefined && count >= options.maxItems) {
+        return;
+      }
+      yield item;
+      count++;
+    }
+    if (options.maxItems !== undefined && count >= options.maxItems) {
+      return;
+    }
+
+    let next: PageCursor | undefined;
+    switch (strategy) {
+      case 'page':
+        // The first page tells the page size; a short or empty page is the last one
+        pageSize = pageSize ?? page.items.length;
+        if (page.items.length > 0 && page.items.length >= pageSize && page.body?.links?.next !== null) {
+          next = { number: (cursor.number ?? 1) + 1 };
+        }
+        break;
+      case 'cursor':
+        if (page.body?.links?.next) {
+          next = { url: page.body.links.next };
+        }
+        break;
+      case 'link': {
+        const url = nextLink(page.response.headers.get('Link'));
+        if (url) {
+          next = { url };
+        }
+        break;
+      }
+    }
+
+    if (!next) {
+      return;
+    }
+    cursor = next;
+  }
+}
+
+/**
+ * Collect all the items yielded by an async iterator
+ */
+export async function collect<T>(iterator: AsyncIterable<T>): Promise<T[]> {
+  const items: T[] = [];
+  for await (const item of iterator) {
+    items.push(item);
+  }
+  return items;
+}
+
+/**
+ * Extract the rel="next" URL of a Link header
+ */
+export function nextLink(header: string | null): string | undefined {
+  if (!header) {
+    return undefined;
+  }
+  for (const part of header.split(',')) {
+    const match = part.match(/<([^>]*)>\s*;(.*)/);
+    if (match && /rel\s*=\s*"?([^";]*\s)?next(\s[^";]*)?"?/.test(match[2])) {
+      return match[1];
+    }
+  }
+  return undefined;
+}
+
+function throwIfAborted(signal?: AbortSignal): void {
+  if (signal?.aborted) {
+    throw signal.reason ?? new Error('The operation was aborted');
+  }
+}
@@ -0,0 +1,127 @@
+// Auto-generated TypeScript SDK runtime
+// Do not modify manually.
+
+/**
+ * QueryValue is a value of a query parameter; arrays repeat the parameter
+ */
+export type QueryValue = string | number | boolean | Array<string | number | boolean> | null | undefined;
+
+/**
+ * RequestOptions configures a single call of an SDK method
+ */
+export interface RequestOptions {
+  /**
+   * Abort the request
+   */
+  signal?: AbortSignal;
+
+  /**
+   * Abort the request if it does not complete within this many milliseconds
+   */
+  timeoutMs?: number;
+
+  /**
+   * Extra headers, overriding the headers set by the SDK
+   */
+  headers?: Record<string, string>;
+
+  /**
+   * Query parameters overriding the ones set by the SDK; null or undefined removes a parameter
+   */
+  query?: Record<string, QueryValue>;
+
+  /**
+   * Custom fetch implementation
+   */
+  fetch?: typeof fetch;
+
+  /**
+   * Resolve to the underlying Response instead of the parsed body. Error responses are not thrown.
+   */
+  raw?: boolean;
+
+  /**
+   * URL of the page to fetch instead of the operation URL, e.g. the next page link of a list
+   * operation. Only paginated operations follow it.
+   */
+  pageUrl?: string;
+}
+
+/**
+ * RawRequestOptions make an SDK method resolve to the underlying Response
+ */
+export type RawRequestOptions = RequestOptions & { raw: true };
+
+/**
+ * DefaultRequestOptions are the request options applied to every call of the SDK
+ */
+export type DefaultRequestOptions = Omit<RequestOptions, 'signal' | 'raw' | 'pageUrl'>;
+
+/**
+ * Merge the options of a call with the SDK defaults. Headers and query parameters are merged,
+ * other options of the call replace the defaults.
+ */
+export function mergeRequestOptions(defaults: DefaultRequestOptions, options: RequestOptions = {}): RequestOptions {
+  return {
+    ...defaults,
+    ...options,
+    headers: { ...defaults.headers, ...options.headers },
+    query: { ...defaults.query, ...options.query },
+  };
+}
+
+/**
+ * Apply query parameter overrides to a URL
+ */
+export function applyQuery(url: string, query?: Record<string, QueryValue>): string {
+  if (!query || Object.keys(query).length === 0) {
+    return url;
+  }
+
+  const [base, search = ''] = url.split('?', 2);
+  const queryString = new URLSearchParams(search);
+  for (const [name, value] of Object.entries(query)) {
+    queryString.delete(name);
+    if (value === null || value === undefined) {
+      continue;
+    }
+    for (const v of Array.isArray(value) ? value : [value]) {
+      queryString.append(name, String(v));
+    }
+  }
+
+  const result = queryString.toString();
+  return result ? `${base}?${result}` : base;
+}
+
+/**
+ * Combine an optional abort signal with a timeout. The returned clear function must be called
+ * once the request completes.
+ */
+export function withTimeout(signal?: AbortSignal, timeoutMs?: number): { signal?: AbortSignal; clear: () => void } {
+  if (timeoutMs === undefined || timeoutMs <= 0) {
+    return { signal, clear: () => undefined };
+  }
+
+  const controller = new AbortController();
+  const onAbort = () => controller.abort(signal?.reason);
+  if (signal?.aborted) {
+    controller.abort(signal.reason);
+  } else {
+    signal?.addEventListener('abort', onAbort, { once: true });
+  }
+
+  const timer = setTimeout(() => {
+    const error = new Error(`The request timed out after ${timeoutMs}ms`);
+    error.name = 'TimeoutError';
+    controller.abort(error);
+  }, timeoutMs);
+
+  return {
+    signal: controller.signal,
+    clear: () => {
+      clearTimeout(timer);
+      signal?.removeEventListener('abort', onAbort);
+    },
+  };
+}
@@ -0,0 +1,60 @@
+// Auto-generated TypeScript SDK runtime
+// Do not modify manually.
+
+/**
+ * ApiResponse is the response of an SDK method called through its WithResponse variant:
+ * the parsed body along with the response status and headers
+ */
+export interface ApiResponse<T, H = {}> {
+  data: T;
+  status: number;
+
+  /**
+   * Response headers declared by the operation, parsed to their types
+   */
+  headers: H;
+
+  /**
+   * All the response headers
+   */
+  rawHeaders: Headers;
+
+  /**
+   * Total number of items of a list, from the total count header requested with the totalCount parameter
+   * or declared by the response
+   */
+  totalCount?: number;
+}
+
+/**
+ * Read a header as a string
+ */
+export function stringHeader(headers: Headers, name: string): string | undefined {
+  return headers.get(name) ?? undefined;
+}
+
+/**
+ * Read a header as a number; missing and non-numeric values are undefined
+ */
+export function numberHeader(headers: Headers, name: string): number | undefined {
+  const value = headers.get(name);
+  if (value === null || value.trim() === '') {
+    return undefined;
+  }
+  const parsed = Number(value);
+  return Number.isNaN(parsed) ? undefined : parsed;
+}
+
+/**
+ * Read a header as a boolean; values other than true and false are undefined
+ */
+export function booleanHeader(headers: Headers, name: string): boolean | undefined {
+  switch (headers.get(name)?.trim().toLowerCase()) {
+    case 'true':
+      return true;
+    case 'false':
+      return false;
+    default:
+      return undefined;
+  }
+}
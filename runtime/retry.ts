@@ -0,0 +1,158 @@
+// Auto-generated TypeScript SDK runtime
+// Do not modify manually.
+
+/**
+ * RetryPolicy configures how the SDK retries requests failing with a transient error
+ */
+export interface RetryPolicy {
+  /**
+   * Maximum number of attempts, including the first request. 1 disables retries.
+   */
+  maxAttempts?: number;
+
+  /**
+   * Delay before the first retry, doubled (see backoffMultiplier) for every further retry
+   */
+  initialDelayMs?: number;
+
+  /**
+   * Upper bound of the delay between two attempts, including Retry-After delays
+   */
+  maxDelayMs?: number;
+
+  /**
+   * Factor applied to the delay after every retry
+   */
+  backoffMultiplier?: number;
+
+  /**
+   * Randomize delays between zero and the computed backoff ("full jitter")
+   */
+  jitter?: boolean;
+
+  /**
+   * Response status codes that are retried
+   */
+  retryableStatusCodes?: number[];
+
+  /**
+   * HTTP methods that are retried. Only idempotent methods are retried by default.
+   */
+  retryableMethods?: string[];
+
+  /**
+   * Retry requests failing with a network error
+   */
+  retryOnNetworkError?: boolean;
+}
+
+/**
+ * DEFAULT_RETRY_POLICY is used for the settings missing from the configured policy
+ */
+export const DEFAULT_RETRY_POLICY: Required<RetryPolicy> = {
+  maxAttempts: 3,
+  initialDelayMs: 500,
+  maxDelayMs: 30_000,
+  backoffMultiplier: 2,
+  jitter: true,
+  retryableStatusCodes: [408, 429, 502, 503, 504],
+  retryableMethods: ['GET', 'HEAD', 'OPTIONS', 'PUT', 'DELETE'],
+  retryOnNetworkError: true,
+};
+
+/**
+ * MAX_INTERCEPTOR_RETRIES bounds the number of times response interceptors can ask
+ * the SDK to retry a request
+ */
+export const MAX_INTERCEPTOR_RETRIES = 5;
+
+/**
+ * Complete a retry policy with the default settings; false disables retries
+ */
+export function resolveRetryPolicy(policy?: RetryPolicy | false): Required<RetryPolicy> {
+  if (policy === false) {
+    return { ...DEFAULT_RETRY_POLICY, maxAttempts: 1 };
+  }
+  return {
+    ...DEFAULT_RETRY_POLICY,
+    ...policy,
+    retryableMethods: (policy?.retryableMethods ?? DEFAULT_RETRY_POLICY.retryableMethods).map((m) => m.toUpperCase()),
+  };
+}
+
+/**
+ * Tell whether a failed attempt should be retried, given its response or network error
+ */
+export function shouldRetry(
+  policy: Required<RetryPolicy>,
+  method: string,
+  attempt: number,
+  response?: Response,
+  error?: unknown,
+): boolean {
+  if (attempt >= policy.maxAttempts || !policy.retryableMethods.includes(method.toUpperCase())) {
+    return false;
+  }
+  if (response) {
+    return policy.retryableStatusCodes.includes(response.status);
+  }
+  // Aborted and timed out requests are never retried
+  const name = (error as { name?: string } | undefined)?.name;
+  if (name === 'AbortError' || name === 'TimeoutError') {
+    return false;
+  }
+  return policy.retryOnNetworkError;
+}
+
+/**
+ * Compute the delay before the next attempt: the Retry-After header of the response if any,
+ * or else an exponential backoff
+ */
+export function retryDelay(policy: Required<RetryPolicy>, attempt: number, response?: Response): number {
+  const retryAfter = parseRetryAfter(response?.headers.get('Retry-After') ?? null);
+  if (retryAfter !== undefined) {
+    return Math.min(retryAfter, policy.maxDelayMs);
+  }
+
+  const backoff = Math.min(policy.initialDelayMs * Math.pow(policy.backoffMultiplier, attempt - 1), policy.maxDelayMs);
+  return policy.jitter ? Math.random() * backoff : backoff;
+}
+
+/**
+ * Parse a Retry-After header, either a number of seconds or an HTTP date, into milliseconds
+ */
+export function parseRetryAfter(header: string | null): number | undefined {
+  if (!header) {
+    return undefined;
+  }
+  const seconds = Number(header);
+  if (!Number.isNaN(seconds)) {
+    return Math.max(0, seconds * 1000);
+  }
+  const date = Date.parse(header);
+  if (!Number.isNaN(date)) {
+    return Math.max(0, date - Date.now());
+  }
+  return undefined;
+}
+
+/**
+ * Wait for the given delay, rejecting early when the signal is aborted
+ */
+export function sleep(ms: number, signal?: AbortSignal | null): Promise<void> {
+  return new Promise((resolve, reject) => {
+    if (signal?.aborted) {
+      reject(signal.reason ?? new Error('The operation was aborted'));
+      return;
+    }
+    const onAbort = () => {
+      clearTimeout(timer);
+      reject(signal?.reason ?? new Error('The operation was aborted'));
+    };
+    const timer = setTimeout(() => {
+      signal?.removeEventListener('abort', onAbort);
+      resolve();
+    }, ms);
+    signal?.addEventListener('abort', onAbort, { once: true });
+  });
+}
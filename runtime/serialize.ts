@@ -0,0 +1,160 @@
+// Auto-generated TypeScript SDK runtime
+// Do not modify manually.
+
+/**
+ * Styles of query parameters, see https://spec.openapis.org/oas/v3.0.3#style-values
+ */
+export type QueryStyle = 'form' | 'spaceDelimited' | 'pipeDelimited' | 'deepObject';
+
+/**
+ * Styles of path parameters
+ */
+export type PathStyle = 'simple' | 'label' | 'matrix';
+
+/**
+ * QueryString builds a query string keeping the encoding of each parameter, so that the
+ * reserved characters of parameters allowing them are sent as they are
+ */
+export class QueryString {
+  private parts: string[] = [];
+
+  /**
+   * Append a parameter, percent-encoding its name and value. With allowReserved, the reserved
+   * characters of the value (e.g. / or ,) are not encoded.
+   */
+  append(name: string, value: string, allowReserved: boolean = false): void {
+    this.parts.push(`${encodeURIComponent(name)}=${allowReserved ? encodeReserved(value) : encodeURIComponent(value)}`);
+  }
+
+  toString(): string {
+    return this.parts.join('&');
+  }
+}
+
+/**
+ * Percent-encode a value, except for the reserved characters of RFC 3986
+ */
+function encodeReserved(value: string): string {
+  return encodeURIComponent(value).replace(/%(3A|2F|3F|23|5B|5D|40|21|24|26|27|28|29|2A|2B|2C|3B|3D)/gi, decodeURIComponent);
+}
+
+/**
+ * Format a scalar parameter value
+ */
+function formatValue(value: unknown): string {
+  return value instanceof Date ? value.toISOString() : String(value);
+}
+
+/**
+ * Entries of an object parameter, without the undefined and null values
+ */
+function objectEntries(value: object): [string, string][] {
+  return Object.entries(value)
+    .filter(([, v]) => v !== undefined && v !== null)
+    .map(([k, v]) => [k, formatValue(v)]);
+}
+
+/**
+ * Whether a parameter value is serialized as an object
+ */
+function isObject(value: unknown): value is object {
+  return typeof value === 'object' && value !== null && !Array.isArray(value) && !(value instanceof Date);
+}
+
+/**
+ * Append a query parameter to a query string according to its style and explode settings.
+ * Exploded arrays repeat the parameter (ids=1&ids=2), others join their items with the
+ * separator of the style (ids=1,2 in form style, ids=1|2 in pipeDelimited style). Objects are
+ * sent as name[key]=value in deepObject style.
+ */
+export function appendQueryParam(query: QueryString, name: string, value: unknown, style: QueryStyle = 'form', explode: boolean = true, allowReserved: boolean = false): void {
+  if (value === undefined || value === null) {
+    return;
+  }
+
+  const separator = style === 'spaceDelimited' ? ' ' : style === 'pipeDelimited' ? '|' : ',';
+
+  if (Array.isArray(value)) {
+    const items = value.filter((v) => v !== undefined && v !== null).map(formatValue);
+    if (explode) {
+      items.forEach((item) => query.append(name, item, allowReserved));
+    } else if (items.length > 0) {
+      query.append(name, items.join(separator), allowReserved);
+    }
+    return;
+  }
+
+  if (isObject(value)) {
+    const entries = objectEntries(value);
+    if (style === 'deepObject') {
+      entries.forEach(([k, v]) => query.append(`${name}[${k}]`, v, allowReserved));
+    } else if (explode) {
+      entries.forEach(([k, v]) => query.append(k, v, allowReserved));
+    } else if (entries.length > 0) {
+      query.append(name, entries.flat().join(separator), allowReserved);
+    }
+    return;
+  }
+
+  query.append(name, formatValue(value), allowReserved);
+}
+
+/**
+ * Serialize a path parameter according to its style and explode settings, e.g. 1,2 in simple
+ * style, .1.2 in exploded label style or ;ids=1;ids=2 in exploded matrix style
+ */
+export function serializePathParam(name: string, value: unknown, style: PathStyle = 'simple', explode: boolean = false): string {
+  const prefix = style === 'label' ? '.' : style === 'matrix' ? ';' : '';
+  const separator = explode && style === 'label' ? '.' : explode && style === 'matrix' ? ';' : ',';
+
+  if (Array.isArray(value)) {
+    const items = value.filter((v) => v !== undefined && v !== null).map((v) => encodeURIComponent(formatValue(v)));
+    if (style === 'matrix') {
+      return explode ? items.map((item) => `;${name}=${item}`).join('') : `;${name}=${items.join(',')}`;
+    }
+    return prefix + items.join(separator);
+  }
+
+  if (isObject(value)) {
+    const entries = objectEntries(value).map(([k, v]) => [encodeURIComponent(k), encodeURIComponent(v)]);
+    if (explode) {
+      return prefix + entries.map(([k, v]) => `${k}=${v}`).join(separator);
+    }
+    const items = entries.flat().join(',');
+    return style === 'matrix' ? `;${name}=${items}` : prefix + items;
+  }
+
+  const item = encodeURIComponent(formatValue(value));
+  return style === 'matrix' ? `;${name}=${item}` : prefix + item;
+}
+
+/**
+ * Serialize a header parameter in simple style: 1,2 for arrays, and role,admin or role=admin
+ * when exploded for objects
+ */
+export function serializeHeaderParam(value: unknown, explode: boolean = false): string {
+  if (Array.isArray(value)) {
+    return value.filter((v) => v !== undefined && v !== null).map(formatValue).join(',');
+  }
+  if (isObject(value)) {
+    const entries = objectEntries(value);
+    return explode ? entries.map(([k, v]) => `${k}=${v}`).join(',') : entries.flat().join(',');
+  }
+  return formatValue(value);
+}
+
+/**
+ * Serialize a cookie parameter in form style, e.g. ids=1,2, or ids=1; ids=2 when exploded, each
+ * value being a separate cookie
+ */
+export function serializeCookieParam(name: string, value: unknown, explode: boolean = true): string {
+  if (Array.isArray(value)) {
+    const items = value.filter((v) => v !== undefined && v !== null).map((v) => encodeURIComponent(formatValue(v)));
+    return explode ? items.map((item) => `${name}=${item}`).join('; ') : `${name}=${items.join(',')}`;
+  }
+  if (isObject(value)) {
+    const entries = objectEntries(value).map(([k, v]) => [encodeURIComponent(k), encodeURIComponent(v)]);
+    return explode ? entries.map(([k, v]) => `${k}=${v}`).join('; ') : `${name}=${entries.flat().join(',')}`;
+  }
+  return `${name}=${encodeURIComponent(formatValue(value))}`;
+}
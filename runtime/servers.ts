@@ -0,0 +1,35 @@
+// Auto-generated TypeScript SDK runtime
+// Do not modify manually.
+
+/**
+ * Substitute the {variables} of a server URL template. Variables that are not given
+ * use their default value.
+ */
+export function resolveServerUrl(
+  template: string,
+  defaults: Record<string, string>,
+  variables: object = {},
+): string {
+  const values: Record<string, string | undefined> = { ...variables };
+  return template
+    .replace(/\{([^}]+)\}/g, (match, name: string) => values[name] ?? defaults[name] ?? match)
+    .replace(/\/+$/, '');
+}
+
+/**
+ * Resolve a relative server URL against the base URL of the SDK: /v2 replaces the path of
+ * https://api.example.com/v1, while v2 is appended to it. The base URL may itself be relative,
+ * e.g. /api behind a same-origin proxy.
+ */
+export function resolveRelativeUrl(url: string, baseUrl: string): string {
+  const origin = /^[a-z][a-z0-9+.-]*:\/\/[^/]*/i.exec(baseUrl)?.[0] ?? '';
+  let resolved: string;
+  if (url.startsWith('//')) {
+    resolved = (/^[a-z][a-z0-9+.-]*:/i.exec(origin)?.[0] ?? '') + url;
+  } else if (url.startsWith('/')) {
+    resolved = origin + url;
+  } else {
+    resolved = `${baseUrl.replace(/\/+$/, '')}/${url.replace(/^\.\//, '')}`;
+  }
+  return resolved.replace(/\/+$/, '');
+}
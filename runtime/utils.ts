@@ -0,0 +1,88 @@
+// Auto-generated TypeScript SDK runtime
+// Do not modify manually.
+
+function isPlainObject(value: unknown): value is Record<string, any> {
+  if (value === null || typeof value !== 'object') {
+    return false;
+  }
+  const proto = Object.getPrototypeOf(value);
+  return proto === Object.prototype || proto === null;
+}
+
+/**
+ * Convert a snake_case key to camelCase
+ */
+export function toCamelCase(key: string): string {
+  return key.replace(/^_/, '').replace(/_([a-z0-9])/g, (_, c: string) => c.toUpperCase());
+}
+
+/**
+ * Convert a camelCase key to snake_case
+ */
+export function toSnakeCase(key: string): string {
+  return key.replace(/([a-z0-9])([A-Z])/g, '$1_$2').toLowerCase();
+}
+
+/**
+ * Transform an API payload into its client representation:
+ * keys are converted to camelCase and `_embedded` resources are promoted
+ * to top-level properties. Values are left untouched.
+ */
+export function toClientType<T = any>(value: unknown): T {
+  if (Array.isArray(value)) {
+    return value.map((item) => toClientType(item)) as any;
+  }
+  if (!isPlainObject(value)) {
+    return value as T;
+  }
+
+  const result: Record<string, any> = {};
+  for (const [key, item] of Object.entries(value)) {
+    if (key === '_embedded' && isPlainObject(item)) {
+      continue;
+    }
+    result[toCamelCase(key)] = toClientType(item);
+  }
+
+  const embedded = value['_embedded'];
+  if (isPlainObject(embedded)) {
+    for (const [key, item] of Object.entries(embedded)) {
+      result[toCamelCase(key)] = toClientType(item);
+    }
+  }
+
+  return result as T;
+}
+
+/**
+ * Transform a client payload into its API representation:
+ * keys are converted to snake_case and the properties listed in
+ * `embeddedObjects` are moved under `_embedded`.
+ */
+export function toApiType<T = any>(value: unknown, embeddedObjects: string[] = []): T {
+  if (Array.isArray(value)) {
+    return value.map((item) => toApiType(item, embeddedObjects)) as any;
+  }
+  if (!isPlainObject(value)) {
+    return value as T;
+  }
+
+  const result: Record<string, any> = {};
+  const embedded: Record<string, any> = {};
+  for (const [key, item] of Object.entries(value)) {
+    if (item === undefined) {
+      continue;
+    }
+    if (embeddedObjects.includes(key)) {
+      embedded[toSnakeCase(key)] = toApiType(item);
+    } else {
+      result[toSnakeCase(key)] = toApiType(item);
+    }
+  }
+
+  if (Object.keys(embedded).length > 0) {
+    result['_embedded'] = embedded;
+  }
+
+  return result as T;
+}
@@ -0,0 +1,359 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is the severity of a diagnostic: errors stop the generation, warnings only do in strict mode
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a problem of the OpenAPI document found before generating the SDK
+type Diagnostic struct {
+	Severity Severity
+	File     string
+	Line     int // 0 when the location is unknown
+	Message  string
+}
+
+// String formats the diagnostic as file:line: severity: message
+func (d Diagnostic) String() string {
+	if d.Line == 0 {
+		return fmt.Sprintf("%s: %s: %s", d.File, d.Severity, d.Message)
+	}
+	return fmt.Sprintf("%s:%d: %s: %s", d.File, d.Line, d.Severity, d.Message)
+}
+
+// Diagnostics are the diagnostics of a document, in document order
+type Diagnostics []Diagnostic
+
+// Failed reports whether the diagnostics stop the generation. In strict mode, warnings do too.
+func (d Diagnostics) Failed(strict bool) bool {
+	for _, diagnostic := range d {
+		if diagnostic.Severity == SeverityError || strict {
+			return true
+		}
+	}
+	return false
+}
+
+// supportedMethods are the HTTP methods of the operations the SDK generates methods for
+var supportedMethods = []string{"GET", "POST", "PATCH", "PUT", "DELETE"}
+
+// supportedRequestContentTypes are the request body content types the SDK can send
+var supportedRequestContentTypes = []string{"application/json", "multipart/form-data"}
+
+// validateDocument runs the OpenAPI validation of the document and the checks of the generator.
+// data is the content of the root document, used to locate the diagnostics in file.
+func validateDocument(doc *openapi3.T, data []byte, file string) Diagnostics {
+	locator := newSourceLocator(data)
+	var diagnostics Diagnostics
+	report := func(severity Severity, path []string, format string, args ...any) {
+		diagnostics = append(diagnostics, Diagnostic{
+			Severity: severity,
+			File:     file,
+			Line:     locator.line(path...),
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	if err := doc.Validate(context.Background()); err != nil {
+		for _, message := range strings.Split(err.Error(), "\n") {
+			message = strings.TrimSpace(message)
+			switch {
+			case message == "":
+			case strings.Contains(message, "must define exactly all path parameters"):
+				// Reported with its location by validateParameters
+			case strings.Contains(message, `unsupported 'type' value "null"`):
+				// The generator supports null branches of the OpenAPI 3.1 form as nullable types
+				report(SeverityWarning, validationErrorPath(message), "%s", message)
+			default:
+				report(SeverityError, validationErrorPath(message), "%s", message)
+			}
+		}
+	}
+
+	if doc.Paths == nil {
+		return diagnostics
+	}
+
+	// Operations by generated method name, to report the ones sharing a name
+	type namedOperation struct {
+		label string
+		path  []string
+	}
+	methodOperations := make(map[string][]namedOperation)
+	for _, path := range doc.Paths.InMatchingOrder() {
+		pathItem := doc.Paths.Find(path)
+		if pathItem == nil {
+			continue
+		}
+		operations := pathItem.Operations()
+
+		for _, method := range sortedKeys(operations) {
+			operation := operations[method]
+			operationPath := []string{"paths", path, strings.ToLower(method)}
+			if !contains(supportedMethods, method) {
+				report(SeverityWarning, operationPath, "%s %s is skipped: the %s method is not supported", method, path, method)
+				continue
+			}
+
+			methodName := generateMethodName(operation, method, path)
+			if name, ok := operation.Extensions["x-gocart-sdk-name"]; ok {
+				if _, isString := name.(string); !isString {
+					report(SeverityError, keyPath(operationPath, "x-gocart-sdk-name"), "x-gocart-sdk-name of %s %s must be a string", method, path)
+				}
+			} else if operation.OperationID == "" {
+				report(SeverityWarning, operationPath, "%s %s has no operationId; its method is named %s", method, path, methodName)
+			}
+			if !identifierPattern.MatchString(methodName) {
+				report(SeverityError, operationPath, "method name %s of %s %s is not a valid TypeScript identifier; set x-gocart-sdk-name", methodName, method, path)
+			}
+			methodOperations[methodName] = append(methodOperations[methodName], namedOperation{method + " " + path, operationPath})
+
+			validateParameters(path, pathItem, operation, operationPath, report)
+			validateIncludes(method, path, operation, operationPath, report)
+			validateContent(method, path, operation, operationPath, report)
+		}
+	}
+
+	for _, name := range sortedKeys(methodOperations) {
+		operations := methodOperations[name]
+		sort.SliceStable(operations, func(i, j int) bool {
+			return locator.line(operations[i].path...) < locator.line(operations[j].path...)
+		})
+		for _, operation := range operations[1:] {
+			report(SeverityError, operation.path, "%s generates the same method %s as %s; set x-gocart-sdk-name", operation.label, name, operations[0].label)
+		}
+	}
+
+	// Diagnostics without a location come first, the others in document order
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Line < diagnostics[j].Line
+	})
+	return diagnostics
+}
+
+// validationSchemaPattern matches the component schema an OpenAPI validation error is about
+var validationSchemaPattern = regexp.MustCompile(`^invalid components: schema "([^"]+)"`)
+
+// validationErrorPath returns the location of an OpenAPI validation error, when it names a component schema
+func validationErrorPath(message string) []string {
+	if match := validationSchemaPattern.FindStringSubmatch(message); match != nil {
+		return []string{"components", "schemas", match[1]}
+	}
+	return nil
+}
+
+// validateParameters checks the parameters of an operation: path parameters must match the
+// path template, and references must be resolved
+func validateParameters(path string, pathItem *openapi3.PathItem, operation *openapi3.Operation, operationPath []string, report func(Severity, []string, string, ...any)) {
+	templateParams := extractPathParams(path)
+
+	declared := make(map[string]bool)
+	for _, params := range []openapi3.Parameters{pathItem.Parameters, operation.Parameters} {
+		for _, paramRef := range params {
+			if paramRef == nil {
+				continue
+			}
+			if paramRef.Value == nil {
+				report(SeverityError, keyPath(operationPath, "parameters"), "unresolved parameter reference %s", paramRef.Ref)
+				continue
+			}
+			if paramRef.Value.Schema != nil && paramRef.Value.Schema.Value == nil {
+				report(SeverityError, keyPath(operationPath, "parameters"), "unresolved schema reference %s of parameter %s", paramRef.Value.Schema.Ref, paramRef.Value.Name)
+			}
+			if paramRef.Value.In != openapi3.ParameterInPath {
+				continue
+			}
+			declared[paramRef.Value.Name] = true
+			if !contains(templateParams, paramRef.Value.Name) {
+				report(SeverityError, keyPath(operationPath, "parameters"), "path parameter %s is not part of the path %s", paramRef.Value.Name, path)
+			}
+		}
+	}
+
+	for _, name := range templateParams {
+		if !declared[name] {
+			report(SeverityError, operationPath, "path parameter %s of %s is not declared", name, path)
+		}
+	}
+}
+
+// validateIncludes checks the x-gocart-includes extensions of an operation and of its include
+// parameter are lists of include values
+func validateIncludes(method, path string, operation *openapi3.Operation, operationPath []string, report func(Severity, []string, string, ...any)) {
+	check := func(extensions map[string]any, extensionPath []string) {
+		if _, ok := extensions["x-gocart-includes"]; !ok {
+			return
+		}
+		values, ok := includesExtension(extensions)
+		if !ok {
+			report(SeverityError, extensionPath, "x-gocart-includes of %s %s must be a list of strings", method, path)
+			return
+		}
+		for _, value := range values {
+			if !includeValuePattern.MatchString(value) {
+				report(SeverityError, extensionPath, "x-gocart-includes of %s %s: %q is not a valid include value", method, path, value)
+			}
+		}
+	}
+
+	check(operation.Extensions, keyPath(operationPath, "x-gocart-includes"))
+	for i, paramRef := range operation.Parameters {
+		if paramRef != nil && paramRef.Value != nil && paramRef.Value.In == openapi3.ParameterInQuery && paramRef.Value.Name == "include" {
+			check(paramRef.Value.Extensions, keyPath(operationPath, "parameters", strconv.Itoa(i), "x-gocart-includes"))
+		}
+	}
+}
+
+// validateContent checks the request body and success responses of an operation have content
+// types the SDK supports, so their types do not silently fall back to any
+func validateContent(method, path string, operation *openapi3.Operation, operationPath []string, report func(Severity, []string, string, ...any)) {
+	if operation.RequestBody != nil {
+		if operation.RequestBody.Value == nil {
+			report(SeverityError, keyPath(operationPath, "requestBody"), "unresolved request body reference %s", operation.RequestBody.Ref)
+		} else {
+			for _, contentType := range sortedContentTypes(operation.RequestBody.Value.Content) {
+				if !contains(supportedRequestContentTypes, contentType) {
+					report(SeverityWarning, keyPath(operationPath, "requestBody", "content", contentType), "request body content type %s of %s %s is not supported and is ignored", contentType, method, path)
+				}
+			}
+		}
+	}
+
+	if operation.Responses == nil {
+		return
+	}
+	hasSuccess := false
+	for _, status := range sortedKeys(operation.Responses.Map()) {
+		if !strings.HasPrefix(status, "2") {
+			continue
+		}
+		hasSuccess = true
+		responsePath := keyPath(operationPath, "responses", status)
+		respRef := operation.Responses.Value(status)
+		if respRef.Value == nil {
+			report(SeverityError, responsePath, "unresolved response reference %s", respRef.Ref)
+			continue
+		}
+		for _, contentType := range sortedContentTypes(respRef.Value.Content) {
+			media := respRef.Value.Content[contentType]
+			if media.Schema != nil && media.Schema.Value == nil {
+				report(SeverityError, keyPath(responsePath, "content", contentType), "unresolved schema reference %s", media.Schema.Ref)
+				continue
+			}
+			if contentType != "application/json" && contentType != "text/html" && !isBinaryContentType(contentType) {
+				report(SeverityWarning, keyPath(responsePath, "content", contentType), "response content type %s of %s %s is not supported; the response is typed as any", contentType, method, path)
+			}
+		}
+	}
+	if !hasSuccess {
+		report(SeverityWarning, keyPath(operationPath, "responses"), "%s %s declares no success response; it resolves to any", method, path)
+	}
+}
+
+// sortedContentTypes returns the content types of a content map in alphabetical order
+func sortedContentTypes(content openapi3.Content) []string {
+	return sortedKeys(content)
+}
+
+// sortedKeys returns the keys of a map in alphabetical order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// keyPath returns the path of keys of an element nested in the element at base
+func keyPath(base []string, keys ...string) []string {
+	return append(append([]string{}, base...), keys...)
+}
+
+// sourceLocator finds the line of the elements of a YAML or JSON document
+type sourceLocator struct {
+	root *yaml.Node
+}
+
+// newSourceLocator parses a document to locate its elements. Documents that cannot be parsed
+// are not located.
+func newSourceLocator(data []byte) *sourceLocator {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil || len(document.Content) == 0 {
+		return &sourceLocator{}
+	}
+	return &sourceLocator{root: document.Content[0]}
+}
+
+// line returns the line of the element at the given path of keys (or sequence indexes), or of
+// its closest ancestor found in the document; 0 if the document could not be parsed
+func (l *sourceLocator) line(path ...string) int {
+	if l.root == nil {
+		return 0
+	}
+
+	node := l.root
+	line := 0
+	for _, key := range path {
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					line = node.Content[i].Line
+					next = node.Content[i+1]
+					break
+				}
+			}
+		case yaml.SequenceNode:
+			if index, err := strconv.Atoi(key); err == nil && index >= 0 && index < len(node.Content) {
+				next = node.Content[index]
+				line = next.Line
+			}
+		}
+		if next == nil {
+			break
+		}
+		node = next
+	}
+	return line
+}
+
+// reportDiagnostics logs the diagnostics of a document and reports whether the SDK can be
+// generated from it
+func reportDiagnostics(diagnostics Diagnostics, strict bool, logf func(format string, args ...any)) bool {
+	errorCount, warningCount := 0, 0
+	for _, diagnostic := range diagnostics {
+		logf("%s", diagnostic)
+		if diagnostic.Severity == SeverityError {
+			errorCount++
+		} else {
+			warningCount++
+		}
+	}
+
+	if !diagnostics.Failed(strict) {
+		return true
+	}
+	if strict && warningCount > 0 {
+		logf("OpenAPI document is invalid: %d error(s), %d warning(s) in strict mode", errorCount, warningCount)
+	} else {
+		logf("OpenAPI document is invalid: %d error(s)", errorCount)
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	// watchInterval is the delay between two checks of the watched files
+	watchInterval = 500 * time.Millisecond
+
+	// watchDebounce is how long the watched files must stay unchanged before regenerating,
+	// so that a burst of saves triggers a single generation
+	watchDebounce = 300 * time.Millisecond
+)
+
+// loadDocumentFile loads an OpenAPI document and its external references from a file. It
+// returns the local files read by the loader, including the document itself, even when
+// loading fails, so they can be watched for a fix.
+func loadDocumentFile(path string) (*openapi3.T, []string, error) {
+	seen := map[string]bool{filepath.Clean(path): true}
+
+	// The default reader caches files for the life of the process, which would hide changes
+	read := openapi3.ReadFromURIs(openapi3.ReadFromHTTP(http.DefaultClient), openapi3.ReadFromFile)
+
+	loader := openapi3.NewLoader()
+	loader.IsExternalRefsAllowed = true
+	loader.ReadFromURIFunc = func(loader *openapi3.Loader, location *url.URL) ([]byte, error) {
+		if location.Scheme == "" || location.Scheme == "file" {
+			seen[filepath.Clean(filepath.FromSlash(location.Path))] = true
+		}
+		return read(loader, location)
+	}
+
+	doc, err := loader.LoadFromFile(path)
+
+	files := make([]string, 0, len(seen))
+	for file := range seen {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+
+	return doc, files, err
+}
+
+// fileStamp identifies a version of a watched file
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+	exists  bool
+}
+
+// statFiles returns the current version of the watched files
+func statFiles(paths []string) map[string]fileStamp {
+	stamps := make(map[string]fileStamp, len(paths))
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			stamps[path] = fileStamp{}
+			continue
+		}
+		stamps[path] = fileStamp{modTime: info.ModTime(), size: info.Size(), exists: true}
+	}
+	return stamps
+}
+
+// stampsEqual reports whether two versions of the watched files are the same
+func stampsEqual(a, b map[string]fileStamp) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, stamp := range a {
+		other, ok := b[path]
+		if !ok || !other.modTime.Equal(stamp.modTime) || other.size != stamp.size || other.exists != stamp.exists {
+			return false
+		}
+	}
+	return true
+}
+
+// generateFromFile loads and validates a document file, then writes the SDK files whose content
+// changed. It returns the files to watch for the next generation.
+func generateFromFile(path, dir string, logf func(format string, args ...any)) []string {
+	doc, files, err := loadDocumentFile(path)
+	if err != nil {
+		logf("Failed to load OpenAPI document: %v", err)
+		return files
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		logf("Failed to read OpenAPI document: %v", err)
+		return files
+	}
+	if !reportDiagnostics(validateDocument(doc, data, path), strict, logf) {
+		return files
+	}
+
+	output, err := renderSDK(doc)
+	if err != nil {
+		logf("Failed to generate SDK: %v", err)
+		return files
+	}
+	written, err := writeOutput(dir, output)
+	if err != nil {
+		logf("%v", err)
+		return files
+	}
+
+	if len(written) == 0 {
+		logf("Generated TypeScript SDK in %s is up to date", dir)
+	} else {
+		logf("Regenerated %s in %s", strings.Join(written, ", "), dir)
+	}
+	return files
+}
+
+// watchDocument generates the SDK from a document file, then regenerates it whenever the
+// document or one of the files it references changes, until stop is closed. Errors are
+// logged and watching goes on.
+func watchDocument(path, dir string, interval, debounce time.Duration, stop <-chan struct{}, logf func(format string, args ...any)) {
+	files := generateFromFile(path, dir, logf)
+	stamps := statFiles(files)
+	logf("Watching %d file(s) for changes", len(files))
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var changedAt time.Time
+	for {
+		select {
+		case <-stop:
+			return
+		case now := <-ticker.C:
+			current := statFiles(files)
+			if !stampsEqual(current, stamps) {
+				stamps = current
+				changedAt = now
+				continue
+			}
+			if changedAt.IsZero() || now.Sub(changedAt) < debounce {
+				continue
+			}
+
+			changedAt = time.Time{}
+			previous := len(files)
+			files = generateFromFile(path, dir, logf)
+			stamps = statFiles(files)
+			if len(files) != previous {
+				logf("Watching %d file(s) for changes", len(files))
+			}
+		}
+	}
+}